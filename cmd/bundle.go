@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"log/slog"
 	"os"
 	"path"
@@ -99,10 +101,191 @@ var bundleRemoveCmd = &cobra.Command{
 	},
 }
 
+var bundleSignCmd = &cobra.Command{
+	Use:   "sign BUNDLE_FILE",
+	Short: "sign the bundle manifest with an ed25519 or ECDSA private key",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		keyFilename := RuntimeConfig.SignatureKey.Value().(string)
+		if keyFilename == "" {
+			return errors.New("a private key is required in --signature-key")
+		}
+		keyFile, err := os.Open(keyFilename)
+		if err != nil {
+			return err
+		}
+
+		bundleFile, err := os.OpenFile(args[0], os.O_RDWR, 0o644)
+		if err != nil {
+			return err
+		}
+		RuntimeConfig.bundleFile = bundleFile
+		RuntimeConfig.signatureKey = keyFile
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gatecheck.SignBundle(RuntimeConfig.bundleFile, RuntimeConfig.signatureKey)
+	},
+}
+
+var bundleVerifyCmd = &cobra.Command{
+	Use:   "verify BUNDLE_FILE",
+	Short: "check the bundle content against the manifest and verify the signature with the public key of the signer",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		RuntimeConfig.signatureKey = nil
+		if keyFilename := RuntimeConfig.SignatureKey.Value().(string); keyFilename != "" {
+			keyFile, err := os.Open(keyFilename)
+			if err != nil {
+				return err
+			}
+			RuntimeConfig.signatureKey = keyFile
+		}
+
+		bundleFile, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		RuntimeConfig.bundleFile = bundleFile
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// the signature is only verified with a key
+		if RuntimeConfig.signatureKey == nil {
+			return gatecheck.VerifyBundle(cmd.OutOrStdout(), RuntimeConfig.bundleFile, nil)
+		}
+		return gatecheck.VerifyBundle(cmd.OutOrStdout(), RuntimeConfig.bundleFile, RuntimeConfig.signatureKey)
+	},
+}
+
+var bundleExtractCmd = &cobra.Command{
+	Use:   "extract BUNDLE_FILE [LABEL...]",
+	Short: "write files from a bundle to a directory by label, glob pattern or tag",
+	Args:  cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		bundleFile, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		RuntimeConfig.bundleFile = bundleFile
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		return gatecheck.ExtractBundle(RuntimeConfig.bundleFile, dir, args[1:], tags)
+	},
+}
+
+var bundleCatCmd = &cobra.Command{
+	Use:   "cat BUNDLE_FILE [LABEL]",
+	Short: "print a file from a bundle by label, glob pattern or tag",
+	Args:  cobra.RangeArgs(1, 2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if tags, _ := cmd.Flags().GetStringSlice("tag"); len(args) == 1 && len(tags) == 0 {
+			return errors.New("need either a label or --tag to select the file")
+		}
+		bundleFile, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		RuntimeConfig.bundleFile = bundleFile
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		label := ""
+		if len(args) == 2 {
+			label = args[1]
+		}
+		return gatecheck.CatBundle(cmd.OutOrStdout(), RuntimeConfig.bundleFile, label, tags)
+	},
+}
+
+var bundleDiffCmd = &cobra.Command{
+	Use:   "diff OLD_BUNDLE_FILE NEW_BUNDLE_FILE",
+	Short: "list the files and findings that changed between two bundles",
+	Args:  cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch format, _ := cmd.Flags().GetString("format"); format {
+		case "table", "ascii", "markdown", "md", "json":
+		default:
+			return errors.New("invalid --format, must be table, markdown, md, or json")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+
+		oldFile, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer oldFile.Close()
+		newFile, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer newFile.Close()
+
+		diff, err := gatecheck.DiffBundles(oldFile, newFile)
+		if err != nil {
+			return err
+		}
+		return gatecheck.EncodeBundleDiffTo(cmd.OutOrStdout(), diff, format)
+	},
+}
+
+var bundleMergeCmd = &cobra.Command{
+	Use:   "merge OUTPUT_BUNDLE_FILE BUNDLE_FILE...",
+	Short: "combine the files of multiple bundles into a new bundle",
+	Args:  cobra.MinimumNArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch policy, _ := cmd.Flags().GetString("on-collision"); policy {
+		case gatecheck.MergeCollisionError, gatecheck.MergeCollisionPrefix, gatecheck.MergeCollisionNewest:
+		default:
+			return errors.New("invalid --on-collision, must be error, prefix, or newest")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, _ := cmd.Flags().GetString("on-collision")
+
+		sources := []gatecheck.BundleSource{}
+		for _, filename := range args[1:] {
+			f, err := os.Open(filename)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			sources = append(sources, gatecheck.BundleSource{Name: filename, Reader: f})
+		}
+
+		// only write the output after a successful merge
+		buf := new(bytes.Buffer)
+		if err := gatecheck.MergeBundles(buf, policy, sources...); err != nil {
+			return err
+		}
+		return os.WriteFile(args[0], buf.Bytes(), 0o644)
+	},
+}
+
 func newBundleCommand() *cobra.Command {
 	RuntimeConfig.BundleTag.SetupCobra(bundleCreateCmd)
 	RuntimeConfig.BundleTag.SetupCobra(bundleAddCmd)
 
-	bundleCmd.AddCommand(bundleCreateCmd, bundleAddCmd, bundleRemoveCmd)
+	RuntimeConfig.SignatureKey.SetupCobra(bundleSignCmd)
+	RuntimeConfig.SignatureKey.SetupCobra(bundleVerifyCmd)
+
+	bundleExtractCmd.Flags().String("dir", ".", "the directory for the extracted files, created if it doesn't exist")
+	_ = bundleExtractCmd.MarkFlagDirname("dir")
+	bundleExtractCmd.Flags().StringSliceP("tag", "t", []string{}, "only files with this tag, ex. image=api")
+	bundleCatCmd.Flags().StringSliceP("tag", "t", []string{}, "only files with this tag, ex. image=api")
+
+	bundleDiffCmd.Flags().String("format", "table", "output format [table|markdown|md|json]")
+
+	bundleMergeCmd.Flags().String("on-collision", gatecheck.MergeCollisionError, "how to merge a label in more than one bundle [error|prefix|newest]")
+
+	bundleCmd.AddCommand(bundleCreateCmd, bundleAddCmd, bundleRemoveCmd, bundleSignCmd, bundleVerifyCmd, bundleExtractCmd, bundleCatCmd, bundleDiffCmd, bundleMergeCmd)
 	return bundleCmd
 }
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gatecheckdev/configkit"
+	"github.com/gatecheckdev/gatecheck/pkg/cache"
+	"github.com/gatecheckdev/gatecheck/pkg/epss"
 	"github.com/gatecheckdev/gatecheck/pkg/gatecheck"
 	"github.com/spf13/cobra"
 )
@@ -22,16 +28,26 @@ type metaConfig struct {
 	EPSSURL         configkit.MetaField
 	KEVURL          configkit.MetaField
 	EPSSFilename    configkit.MetaField
+	EPSSDate        configkit.MetaField
 	KEVFilename     configkit.MetaField
 	Verbose         configkit.MetaField
 	Silent          configkit.MetaField
 	ConfigFilename  configkit.MetaField
 	Audit           configkit.MetaField
+	FailFast        configkit.MetaField
+	Offline         configkit.MetaField
+	CacheMaxAge     configkit.MetaField
+	SignatureKey    configkit.MetaField
+	RequireSign     configkit.MetaField
 	BundleTagValue  []string
 	bundleFile      *os.File
 	targetFile      *os.File
 	epssFile        *os.File
 	kevFile         *os.File
+	junitFile       *os.File
+	sarifFile       *os.File
+	baselineFile    *os.File
+	signatureKey    *os.File
 	listSrcReader   io.Reader
 	listSrcName     string
 	listFormat      string
@@ -109,6 +125,22 @@ var RuntimeConfig = metaConfig{
 			metadataActionInputName: "epss_filename",
 		},
 	},
+	EPSSDate: configkit.MetaField{
+		FieldName:    "EPSSDate",
+		EnvKey:       "GATECHECK_EPSS_DATE",
+		DefaultValue: "",
+		FlagValueP:   new(string),
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*string)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().StringVar(valueP, "epss-date", "", usage)
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "use the EPSS scores published on this date YYYY-MM-DD instead of the current scores",
+			metadataFieldType:       "string",
+			metadataActionInputName: "epss_date",
+		},
+	},
 	KEVFilename: configkit.MetaField{
 		FieldName:    "KEVFilename",
 		EnvKey:       "GATECHECK_EPSS_FILENAME",
@@ -120,7 +152,7 @@ var RuntimeConfig = metaConfig{
 			cmd.Flags().StringVar(valueP, "kev-filename", "", usage)
 		},
 		Metadata: map[string]string{
-			metadataFlagUsage:       "the filename for a FIRST.org KEV json file",
+			metadataFlagUsage:       "the filename for a CISA KEV json or csv file",
 			metadataFieldType:       "string",
 			metadataActionInputName: "kev_filename",
 		},
@@ -189,4 +221,145 @@ var RuntimeConfig = metaConfig{
 			metadataActionInputName: "audit",
 		},
 	},
+	FailFast: configkit.MetaField{
+		FieldName:    "FailFast",
+		EnvKey:       "GATECHECK_FAIL_FAST",
+		DefaultValue: false,
+		FlagValueP:   new(bool),
+		EnvToValueFunc: func(s string) any {
+			failFast, _ := strconv.ParseBool(s)
+			return failFast
+		},
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*bool)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().BoolVar(valueP, "fail-fast", false, usage)
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "stop validating a report at the first failed rule instead of reporting all failures",
+			metadataFieldType:       "bool",
+			metadataActionInputName: "fail_fast",
+		},
+	},
+	SignatureKey: configkit.MetaField{
+		FieldName:    "SignatureKey",
+		EnvKey:       "GATECHECK_SIGNATURE_KEY",
+		DefaultValue: "",
+		FlagValueP:   new(string),
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*string)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().StringVar(valueP, "signature-key", "", usage)
+			_ = cmd.MarkFlagFilename("signature-key", "pem", "key", "pub")
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "a PEM encoded ed25519 or ECDSA key file, the private key to sign or the public key to verify a bundle",
+			metadataFieldType:       "string",
+			metadataActionInputName: "signature_key",
+		},
+	},
+	RequireSign: configkit.MetaField{
+		FieldName:    "RequireSign",
+		EnvKey:       "GATECHECK_REQUIRE_SIGNATURE",
+		DefaultValue: false,
+		FlagValueP:   new(bool),
+		EnvToValueFunc: func(s string) any {
+			required, _ := strconv.ParseBool(s)
+			return required
+		},
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*bool)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().BoolVar(valueP, "require-signature", false, usage)
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "refuse unsigned bundles and bundles not signed by the --signature-key public key",
+			metadataFieldType:       "bool",
+			metadataActionInputName: "require_signature",
+		},
+	},
+	Offline: configkit.MetaField{
+		FieldName:    "Offline",
+		EnvKey:       "GATECHECK_OFFLINE",
+		DefaultValue: false,
+		FlagValueP:   new(bool),
+		EnvToValueFunc: func(s string) any {
+			offline, _ := strconv.ParseBool(s)
+			return offline
+		},
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*bool)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().BoolVar(valueP, "offline", false, usage)
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "only use cached EPSS and KEV data, fails if the cache is missing or stale",
+			metadataFieldType:       "bool",
+			metadataActionInputName: "offline",
+		},
+	},
+	CacheMaxAge: configkit.MetaField{
+		FieldName:    "CacheMaxAge",
+		EnvKey:       "GATECHECK_CACHE_MAX_AGE",
+		DefaultValue: cache.DefaultMaxAge,
+		FlagValueP:   new(time.Duration),
+		EnvToValueFunc: func(s string) any {
+			maxAge, err := time.ParseDuration(s)
+			if err != nil {
+				slog.Warn("invalid cache max age, default used", "value", s, "error", err)
+				return nil
+			}
+			return maxAge
+		},
+		CobraSetupFunc: func(f configkit.MetaField, cmd *cobra.Command) {
+			valueP := f.FlagValueP.(*time.Duration)
+			usage := f.Metadata[metadataFlagUsage]
+			cmd.Flags().DurationVar(valueP, "cache-max-age", 0, usage)
+		},
+		Metadata: map[string]string{
+			metadataFlagUsage:       "cached EPSS and KEV data older than this is downloaded again (default 24h)",
+			metadataFieldType:       "duration",
+			metadataActionInputName: "cache_max_age",
+		},
+	},
+}
+
+// runtimeEPSSDate the requested EPSS score date, a zero time for the current scores
+func runtimeEPSSDate() (time.Time, error) {
+	value := RuntimeConfig.EPSSDate.Value().(string)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(epss.DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --epss-date %q, must be YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// newRuntimeCache the EPSS and KEV cache from the offline and max age settings
+//
+// The cache is disabled if the cache directory can't be determined, unless offline
+func newRuntimeCache(cmd *cobra.Command) (*cache.Cache, error) {
+	offline := RuntimeConfig.Offline.Value().(bool)
+	dir, err := cache.DefaultDir()
+	switch {
+	case err != nil && offline:
+		return nil, err
+	case err != nil:
+		slog.Warn("epss and kev cache disabled", "error", err)
+		return nil, nil
+	}
+
+	maxAge := RuntimeConfig.CacheMaxAge.Value().(time.Duration)
+	// a zero flag value is treated as unset, --cache-max-age 0 always downloads
+	if cmd.Flags().Changed("cache-max-age") {
+		maxAge = *RuntimeConfig.CacheMaxAge.FlagValueP.(*time.Duration)
+	}
+
+	return &cache.Cache{
+		Dir:     dir,
+		MaxAge:  maxAge,
+		Offline: offline,
+	}, nil
 }
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"log/slog"
+
+	"github.com/gatecheckdev/gatecheck/pkg/cache"
 	"github.com/gatecheckdev/gatecheck/pkg/gatecheck"
 	"github.com/spf13/cobra"
 )
 
 var downloadCmd = &cobra.Command{
 	Use:   "download",
-	Short: "output data from supported APIs",
+	Short: "output data from supported APIs and update the local cache",
 }
 
 var downloadEPSSCmd = &cobra.Command{
@@ -15,7 +18,15 @@ var downloadEPSSCmd = &cobra.Command{
 	Short: "download epss data from FIRST API as csv to STDOUT",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		url := RuntimeConfig.EPSSURL.Value().(string)
-		return gatecheck.DownloadEPSS(cmd.OutOrStdout(), gatecheck.WithEPSSURL(url))
+		date, err := runtimeEPSSDate()
+		if err != nil {
+			return err
+		}
+		return gatecheck.DownloadEPSS(cmd.OutOrStdout(),
+			gatecheck.WithEPSSURL(url),
+			gatecheck.WithEPSSDate(date),
+			gatecheck.WithCache(newDownloadCache()),
+		)
 	},
 }
 
@@ -24,12 +35,23 @@ var downloadKEVCmd = &cobra.Command{
 	Short: "download kev catalog from CISA as json to STDOUT",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		url := RuntimeConfig.KEVURL.Value().(string)
-		return gatecheck.DownloadKEV(cmd.OutOrStdout(), gatecheck.WithKEVURL(url))
+		return gatecheck.DownloadKEV(cmd.OutOrStdout(), gatecheck.WithKEVURL(url), gatecheck.WithCache(newDownloadCache()))
 	},
 }
 
+// newDownloadCache the cache is always revalidated so downloads refresh the data used by offline runs
+func newDownloadCache() *cache.Cache {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		slog.Warn("epss and kev cache not updated", "error", err)
+		return nil
+	}
+	return &cache.Cache{Dir: dir, MaxAge: 0}
+}
+
 func newDownloadCommand() *cobra.Command {
 	RuntimeConfig.EPSSURL.SetupCobra(downloadEPSSCmd)
+	RuntimeConfig.EPSSDate.SetupCobra(downloadEPSSCmd)
 	RuntimeConfig.KEVURL.SetupCobra(downloadKEVCmd)
 	downloadCmd.AddCommand(downloadEPSSCmd, downloadKEVCmd)
 	return downloadCmd
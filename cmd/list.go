@@ -6,14 +6,12 @@ import (
 	"log/slog"
 	"os"
 	"slices"
-	"strings"
 
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
 	"github.com/gatecheckdev/gatecheck/pkg/gatecheck"
 	"github.com/spf13/cobra"
 )
 
-var supportedTypes = []string{"grype", "semgrep", "gitleaks", "syft", "cyclonedx", "bundle", "gatecheck"}
-
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "print a table of the findings in a report or files in a gatecheck bundle",
@@ -44,7 +42,36 @@ var listCmd = &cobra.Command{
 			RuntimeConfig.listFormat = "markdown"
 		}
 
-		if epss, _ := cmd.Flags().GetBool("epss"); !epss {
+		switch formatFlag, _ := cmd.Flags().GetString("format"); formatFlag {
+		case "":
+		case "ascii", "markdown", "md", "sarif":
+			RuntimeConfig.listFormat = formatFlag
+		default:
+			return errors.New("invalid --format, must be ascii, markdown, md, or sarif")
+		}
+
+		riskScore, _ := cmd.Flags().GetBool("risk-score")
+
+		if riskScore {
+			RuntimeConfig.gatecheckConfig = gatecheck.NewDefaultConfig()
+			if configFilename := RuntimeConfig.ConfigFilename.Value().(string); configFilename != "" {
+				err = gatecheck.NewConfigDecoder(configFilename).Decode(RuntimeConfig.gatecheckConfig)
+				if err != nil {
+					return err
+				}
+			}
+
+			RuntimeConfig.kevFile = nil
+			if kevFilename := RuntimeConfig.KEVFilename.Value().(string); kevFilename != "" {
+				RuntimeConfig.kevFile, err = os.Open(kevFilename)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// the risk score uses EPSS data
+		if epss, _ := cmd.Flags().GetBool("epss"); !epss && !riskScore {
 			return nil
 		}
 
@@ -65,26 +92,49 @@ var listCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		epss, _ := cmd.Flags().GetBool("epss")
+		riskScore, _ := cmd.Flags().GetBool("risk-score")
 
 		dst := cmd.OutOrStdout()
 		src := RuntimeConfig.listSrcReader
 		srcName := RuntimeConfig.listSrcName
 		displayOpt := gatecheck.WithDisplayFormat(RuntimeConfig.listFormat)
 
-		if !epss {
+		if !epss && !riskScore {
 			return gatecheck.List(dst, src, srcName, displayOpt)
 		}
 
 		epssURL := RuntimeConfig.EPSSURL.Value().(string)
 		epssFile := RuntimeConfig.epssFile
 
+		epssCache, err := newRuntimeCache(cmd)
+		if err != nil {
+			return err
+		}
+
+		epssDate, err := runtimeEPSSDate()
+		if err != nil {
+			return err
+		}
+
 		// if file is nil, API will be used
 		// if epssURL is empty, default API will be used
-		epssOpt, err := gatecheck.WithEPSS(epssFile, epssURL)
+		epssOpt, err := gatecheck.WithEPSS(epssFile, epssURL, gatecheck.WithCache(epssCache), gatecheck.WithEPSSDate(epssDate))
 		if err != nil {
 			return err
 		}
-		return gatecheck.List(dst, src, srcName, displayOpt, epssOpt)
+
+		if !riskScore {
+			return gatecheck.List(dst, src, srcName, displayOpt, epssOpt)
+		}
+
+		// if file is nil, API will be used
+		kevURL := RuntimeConfig.KEVURL.Value().(string)
+		kevOpt, err := gatecheck.WithKEV(RuntimeConfig.kevFile, kevURL, gatecheck.WithCache(epssCache))
+		if err != nil {
+			return err
+		}
+		riskOpt := gatecheck.WithRiskScore(RuntimeConfig.gatecheckConfig)
+		return gatecheck.List(dst, src, srcName, displayOpt, epssOpt, kevOpt, riskOpt)
 	},
 }
 
@@ -96,21 +146,25 @@ var listAllCmd = &cobra.Command{
 		markdown, _ := cmd.Flags().GetBool("markdown")
 		slog.Debug("run list all", "epss", fmt.Sprintf("%v", epss), "markdown", fmt.Sprintf("%v", markdown))
 
+		epssCache, err := newRuntimeCache(cmd)
+		if err != nil {
+			return err
+		}
+
 		for _, filename := range args {
-			supportedFunc := func(s string) bool {
-				return strings.Contains(filename, s)
-			}
 			cmd.Printf("%s\n", filename)
-			if !slices.ContainsFunc(supportedTypes, supportedFunc) {
-				slog.Warn("file not supported, skip", "filename", filename)
-				continue
-			}
 
 			if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
 				slog.Error("file not found, skip", "filename", filename)
 				continue
 			}
 
+			artifactType, err := detectFileType(filename)
+			if err != nil {
+				slog.Warn("file not supported, skip", "filename", filename, "error", err)
+				continue
+			}
+
 			epssURL := RuntimeConfig.EPSSURL.Value().(string)
 			epssFile := RuntimeConfig.epssFile
 
@@ -121,8 +175,8 @@ var listAllCmd = &cobra.Command{
 			}
 			opts = append(opts, displayOpt)
 
-			if epss && slices.ContainsFunc([]string{"grype", "cyclonedx"}, supportedFunc) {
-				epssOpt, err := gatecheck.WithEPSS(epssFile, epssURL)
+			if epss && slices.Contains([]string{artifacts.TypeGrype, artifacts.TypeCyclonedx, artifacts.TypeTrivy}, artifactType) {
+				epssOpt, err := gatecheck.WithEPSS(epssFile, epssURL, gatecheck.WithCache(epssCache))
 				if err != nil {
 					slog.Error("epss fetch failure, skip", "filename", filename, "error", err)
 					continue
@@ -148,17 +202,40 @@ var listAllCmd = &cobra.Command{
 	},
 }
 
+// detectFileType the artifact type from the filename, or the file content if the filename is ambiguous
+func detectFileType(filename string) (string, error) {
+	if artifactType := artifacts.TypeFromFilename(filename); artifactType != "" {
+		return artifactType, nil
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return artifacts.Detect(f)
+}
+
 func newListAllCommand() *cobra.Command {
 	listAllCmd.Flags().Bool("markdown", false, "print as a markdown table")
 	listAllCmd.Flags().Bool("epss", false, "List with EPSS data")
+	RuntimeConfig.Offline.SetupCobra(listAllCmd)
+	RuntimeConfig.CacheMaxAge.SetupCobra(listAllCmd)
 	return listAllCmd
 }
 
 func newListCommand() *cobra.Command {
-	listCmd.Flags().StringP("input-type", "i", "", "the input filetype if using STDIN [grype|semgrep|gitleaks|syft|bundle]")
+	listCmd.Flags().StringP("input-type", "i", "", "the input filetype if using STDIN [grype|semgrep|gitleaks|syft|cyclonedx|trivy|sarif|bundle]")
 	listCmd.Flags().Bool("markdown", false, "print as a markdown table")
+	listCmd.Flags().String("format", "", "output format [ascii|markdown|md|sarif]")
 	listCmd.Flags().Bool("epss", false, "List with EPSS data")
+	listCmd.Flags().Bool("risk-score", false, "List sorted by the risk score with EPSS and KEV data")
 	RuntimeConfig.EPSSURL.SetupCobra(listCmd)
 	RuntimeConfig.EPSSFilename.SetupCobra(listCmd)
+	RuntimeConfig.EPSSDate.SetupCobra(listCmd)
+	RuntimeConfig.KEVURL.SetupCobra(listCmd)
+	RuntimeConfig.KEVFilename.SetupCobra(listCmd)
+	RuntimeConfig.ConfigFilename.SetupCobra(listCmd)
+	RuntimeConfig.Offline.SetupCobra(listCmd)
+	RuntimeConfig.CacheMaxAge.SetupCobra(listCmd)
 	return listCmd
 }
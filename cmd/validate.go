@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -14,6 +15,12 @@ var validateCmd = &cobra.Command{
 	Short: "compare vulnerabilities to configured thresholds",
 	Args:  cobra.ExactArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch output, _ := cmd.Flags().GetString("output"); output {
+		case "", "json", "yaml", "yml":
+		default:
+			return errors.New("invalid --output format, must be json, yaml, or yml")
+		}
+
 		configFilename := RuntimeConfig.ConfigFilename.Value().(string)
 
 		RuntimeConfig.gatecheckConfig = gatecheck.NewDefaultConfig()
@@ -42,6 +49,42 @@ var validateCmd = &cobra.Command{
 			return err
 		}
 
+		RuntimeConfig.junitFile = nil
+		if junitFilename, _ := cmd.Flags().GetString("junit"); junitFilename != "" {
+			RuntimeConfig.junitFile, err = os.Create(junitFilename)
+		}
+		if err != nil {
+			return err
+		}
+
+		RuntimeConfig.sarifFile = nil
+		if sarifFilename, _ := cmd.Flags().GetString("sarif"); sarifFilename != "" {
+			RuntimeConfig.sarifFile, err = os.Create(sarifFilename)
+		}
+		if err != nil {
+			return err
+		}
+
+		RuntimeConfig.signatureKey = nil
+		if RuntimeConfig.RequireSign.Value().(bool) {
+			signatureKeyFilename := RuntimeConfig.SignatureKey.Value().(string)
+			if signatureKeyFilename == "" {
+				return errors.New("--require-signature needs the public key in --signature-key")
+			}
+			RuntimeConfig.signatureKey, err = os.Open(signatureKeyFilename)
+		}
+		if err != nil {
+			return err
+		}
+
+		RuntimeConfig.baselineFile = nil
+		if baselineFilename, _ := cmd.Flags().GetString("baseline"); baselineFilename != "" {
+			RuntimeConfig.baselineFile, err = os.Open(baselineFilename)
+		}
+		if err != nil {
+			return err
+		}
+
 		targetFilename := args[0]
 		slog.Debug("open target file", "filename", targetFilename)
 		RuntimeConfig.targetFile, err = os.Open(targetFilename)
@@ -51,17 +94,57 @@ var validateCmd = &cobra.Command{
 
 		return nil
 	},
-	RunE: func(cmd *cobra.Command, args []string) error {
-		err := gatecheck.Validate(
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		// a report file that fails to close can be truncated
+		defer func() {
+			err = errors.Join(err, closeReportFiles())
+		}()
+
+		output, _ := cmd.Flags().GetString("output")
+
+		epssKEVCache, err := newRuntimeCache(cmd)
+		if err != nil {
+			return err
+		}
+
+		epssDate, err := runtimeEPSSDate()
+		if err != nil {
+			return err
+		}
+
+		result, err := gatecheck.Validate(
 			RuntimeConfig.gatecheckConfig,
 			RuntimeConfig.targetFile,
 			args[0],
 			gatecheck.WithEPSSURL(RuntimeConfig.EPSSURL.Value().(string)),
 			gatecheck.WithKEVURL(RuntimeConfig.KEVURL.Value().(string)),
 			gatecheck.WithEPSSFile(RuntimeConfig.epssFile), // TODO: fix this
+			gatecheck.WithEPSSDate(epssDate),
 			gatecheck.WithKEVFile(RuntimeConfig.kevFile),
+			gatecheck.WithFailFast(RuntimeConfig.FailFast.Value().(bool)),
+			gatecheck.WithBaselineFile(RuntimeConfig.baselineFile),
+			gatecheck.WithCache(epssKEVCache),
+			gatecheck.WithRequireSignature(RuntimeConfig.signatureKey),
 		)
 
+		if output != "" {
+			if encodeErr := gatecheck.EncodeValidationResultTo(cmd.OutOrStdout(), result, output); encodeErr != nil {
+				return encodeErr
+			}
+		}
+
+		if RuntimeConfig.junitFile != nil {
+			if encodeErr := gatecheck.EncodeJUnitTo(RuntimeConfig.junitFile, result); encodeErr != nil {
+				return encodeErr
+			}
+		}
+
+		if RuntimeConfig.sarifFile != nil {
+			if encodeErr := gatecheck.EncodeSarifTo(RuntimeConfig.sarifFile, result); encodeErr != nil {
+				return encodeErr
+			}
+		}
+
 		audit := RuntimeConfig.Audit.Value().(bool)
 		if audit && err != nil {
 			slog.Error("validation failure in audit mode")
@@ -73,12 +156,36 @@ var validateCmd = &cobra.Command{
 	},
 }
 
+// closeReportFiles closes the report files created for the validation result
+func closeReportFiles() error {
+	var errs error
+	for _, f := range []*os.File{RuntimeConfig.junitFile, RuntimeConfig.sarifFile} {
+		if f != nil {
+			errs = errors.Join(errs, f.Close())
+		}
+	}
+	return errs
+}
+
 func newValidateCommand() *cobra.Command {
+	validateCmd.Flags().StringP("output", "o", "", "write the validation result to STDOUT formats=[json yaml yml]")
+	validateCmd.Flags().String("junit", "", "write the validation result as a JUnit XML report to this file")
+	_ = validateCmd.MarkFlagFilename("junit", "xml")
+	validateCmd.Flags().String("sarif", "", "write the failed and risk accepted findings as a SARIF 2.1.0 log to this file")
+	_ = validateCmd.MarkFlagFilename("sarif", "sarif", "json")
+	validateCmd.Flags().String("baseline", "", "a previous report or bundle, limits only apply to findings not in the baseline")
+	_ = validateCmd.MarkFlagFilename("baseline", "json", "gz", "tar.gz")
 
 	RuntimeConfig.ConfigFilename.SetupCobra(validateCmd)
 	RuntimeConfig.EPSSFilename.SetupCobra(validateCmd)
+	RuntimeConfig.EPSSDate.SetupCobra(validateCmd)
 	RuntimeConfig.KEVFilename.SetupCobra(validateCmd)
 	RuntimeConfig.Audit.SetupCobra(validateCmd)
+	RuntimeConfig.FailFast.SetupCobra(validateCmd)
+	RuntimeConfig.Offline.SetupCobra(validateCmd)
+	RuntimeConfig.CacheMaxAge.SetupCobra(validateCmd)
+	RuntimeConfig.RequireSign.SetupCobra(validateCmd)
+	RuntimeConfig.SignatureKey.SetupCobra(validateCmd)
 
 	return validateCmd
 }
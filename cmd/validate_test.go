@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gatecheckdev/gatecheck/pkg/cache"
+	"github.com/spf13/cobra"
+)
+
+func TestValidateCmd_failFastEnv(t *testing.T) {
+	t.Setenv("GATECHECK_FAIL_FAST", "true")
+
+	if failFast, ok := RuntimeConfig.FailFast.Value().(bool); !ok || !failFast {
+		t.Fatalf("want: true got: %#v", RuntimeConfig.FailFast.Value())
+	}
+
+	cmd := NewGatecheckCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"validate", "--silent", "-o", "json", "../test/grype-report.json"})
+
+	// the default config has no enabled limits, a string env value used to panic here
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("want: no error got: %v", err)
+	}
+}
+
+func TestNewRuntimeCache_maxAge(t *testing.T) {
+	t.Setenv("GATECHECK_CACHE_DIR", t.TempDir())
+
+	testTable := []struct {
+		label string
+		args  []string
+		want  time.Duration
+	}{
+		{label: "default", args: []string{}, want: cache.DefaultMaxAge},
+		{label: "flag", args: []string{"--cache-max-age", "1h"}, want: time.Hour},
+		{label: "zero-flag", args: []string{"--cache-max-age", "0"}, want: 0},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "validate"}
+			RuntimeConfig.CacheMaxAge.SetupCobra(cmd)
+			if err := cmd.ParseFlags(testCase.args); err != nil {
+				t.Fatal(err)
+			}
+			runtimeCache, err := newRuntimeCache(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runtimeCache.MaxAge != testCase.want {
+				t.Fatalf("want: %s got: %s", testCase.want, runtimeCache.MaxAge)
+			}
+		})
+	}
+}
+
+func TestCloseReportFiles(t *testing.T) {
+	t.Cleanup(func() {
+		RuntimeConfig.junitFile = nil
+		RuntimeConfig.sarifFile = nil
+	})
+
+	junitFile, err := os.Create(filepath.Join(t.TempDir(), "junit.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sarifFile, err := os.Create(filepath.Join(t.TempDir(), "gatecheck.sarif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RuntimeConfig.junitFile = junitFile
+	RuntimeConfig.sarifFile = sarifFile
+
+	if err := closeReportFiles(); err != nil {
+		t.Fatalf("want: no error got: %v", err)
+	}
+	for _, f := range []*os.File{junitFile, sarifFile} {
+		if _, err := f.WriteString("{}"); !errors.Is(err, os.ErrClosed) {
+			t.Fatalf("%s want: %v got: %v", f.Name(), os.ErrClosed, err)
+		}
+	}
+
+	// the close error is returned so a truncated report fails the command
+	if err := closeReportFiles(); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("want: %v got: %v", os.ErrClosed, err)
+	}
+}
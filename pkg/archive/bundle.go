@@ -13,6 +13,8 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -35,6 +37,9 @@ const ManifestFilename = "gatecheck-manifest.json"
 // DefaultBundleFilename the bundle name to be used as a default
 const DefaultBundleFilename = "gatecheck-bundle.tar.gz"
 
+// ErrIntegrity the bundle content doesn't match the manifest
+var ErrIntegrity = errors.New("gatecheck bundle integrity check failed")
+
 // Manifest is created and loaded into a bundle which contains information on the files
 type Manifest struct {
 	Created time.Time                 `json:"createdAt"`
@@ -54,8 +59,9 @@ type fileDescriptor struct {
 
 // Bundle uses tar and gzip to collect reports and files into a single file
 type Bundle struct {
-	content  map[string][]byte
-	manifest Manifest
+	content   map[string][]byte
+	manifest  Manifest
+	signature *Signature
 }
 
 // NewBundle ...
@@ -88,6 +94,63 @@ func (b *Bundle) FileBytes(fileLabel string) []byte {
 	return fileBytes
 }
 
+// Labels the file labels in the manifest sorted by label
+func (b *Bundle) Labels() []string {
+	labels, _ := b.Select(nil, nil)
+	return labels
+}
+
+// AddedAt the time the file was added to a bundle
+func (b *Bundle) AddedAt(label string) time.Time {
+	return b.manifest.Files[label].Added
+}
+
+// CopyFile copy a file from another bundle with its manifest entry, so the tags, digest and added time are kept
+func (b *Bundle) CopyFile(src *Bundle, srcLabel string, label string) error {
+	descriptor, ok := src.manifest.Files[srcLabel]
+	if !ok {
+		return fmt.Errorf("gatecheck bundle: Label '%s' not found in bundle", srcLabel)
+	}
+	b.dropSignature()
+	b.manifest.Files[label] = descriptor
+	b.content[label] = src.content[srcLabel]
+	return nil
+}
+
+// Select the labels that match any of the glob patterns and have every tag, sorted by label
+//
+// Patterns use path.Match syntax, ex. "grype-*.json". All files match if there are no patterns
+func (b *Bundle) Select(patterns []string, tags []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid label pattern %q: %w", pattern, err)
+		}
+	}
+
+	labels := []string{}
+	for label, descriptor := range b.manifest.Files {
+		if label == ManifestFilename {
+			continue
+		}
+		matched := len(patterns) == 0
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, label); ok {
+				matched = true
+				break
+			}
+		}
+		hasTags := true
+		for _, tag := range tags {
+			hasTags = hasTags && slices.Contains(descriptor.Tags, tag)
+		}
+		if matched && hasTags {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
 // FileSize get the file size for a specific label
 func (b *Bundle) FileSize(fileLabel string) int {
 	fileBytes, ok := b.content[fileLabel]
@@ -108,6 +171,9 @@ func (b *Bundle) AddFrom(r io.Reader, label string, properties map[string]string
 	}
 	digest := fmt.Sprintf("%x", hasher.Sum(nil))
 
+	if label != ManifestFilename {
+		b.dropSignature()
+	}
 	b.manifest.Files[label] = fileDescriptor{Added: time.Now(), Properties: properties, Digest: digest}
 
 	b.content[label] = p
@@ -120,6 +186,7 @@ func (b *Bundle) Add(content []byte, label string, tags []string) {
 	slog.Debug("bundle add hash content", "error", hashErr, "bytes_hashed", n)
 	digest := hex.EncodeToString(hasher.Sum(nil))
 
+	b.dropSignature()
 	b.manifest.Files[label] = fileDescriptor{
 		Added:  time.Now(),
 		Tags:   tags,
@@ -136,6 +203,7 @@ func (b *Bundle) Remove(label string) {
 	if _, ok := b.content[label]; !ok {
 		slog.Error("file does not exist", "label", label)
 	}
+	b.dropSignature()
 	delete(b.content, label)
 	delete(b.manifest.Files, label)
 }
@@ -144,10 +212,20 @@ func (b *Bundle) Remove(label string) {
 //
 // Deprecated: use Remove
 func (b *Bundle) Delete(label string) {
+	b.dropSignature()
 	delete(b.content, label)
 	delete(b.manifest.Files, label)
 }
 
+// dropSignature the manifest is changing so the signature will no longer match
+func (b *Bundle) dropSignature() {
+	if b.signature == nil {
+		return
+	}
+	slog.Warn("gatecheck bundle changed, signature removed, sign the bundle again", "key_id", b.signature.KeyID)
+	b.signature = nil
+}
+
 func (b *Bundle) Content() string {
 	matrix := format.NewSortableMatrix(make([][]string, 0), 0, format.AlphabeticLess)
 
@@ -182,6 +260,11 @@ func TarGzipBundle(dst io.Writer, bundle *Bundle) (int64, error) {
 		_ = tarWriter.WriteHeader(&tar.Header{Name: label, Size: int64(len(data)), Mode: int64(os.FileMode(0o666))})
 		_, _ = bytes.NewReader(data).WriteTo(tarWriter)
 	}
+	if bundle.signature != nil {
+		signatureBytes, _ := json.Marshal(bundle.signature)
+		_ = tarWriter.WriteHeader(&tar.Header{Name: SignatureFilename, Size: int64(len(signatureBytes)), Mode: int64(os.FileMode(0o666))})
+		_, _ = tarWriter.Write(signatureBytes)
+	}
 	if err := tarWriter.Close(); err != nil {
 		return 0, err
 	}
@@ -196,6 +279,74 @@ func TarGzipBundle(dst io.Writer, bundle *Bundle) (int64, error) {
 	return n, err
 }
 
+// Drift the differences between the manifest and the file content of a bundle
+type Drift struct {
+	// Changed labels with content that doesn't match the manifest digest
+	Changed []string
+	// Missing labels in the manifest without a file
+	Missing []string
+	// Unlisted files without a manifest entry
+	Unlisted []string
+}
+
+// Empty true if the content matches the manifest
+func (d Drift) Empty() bool {
+	return len(d.Changed) == 0 && len(d.Missing) == 0 && len(d.Unlisted) == 0
+}
+
+func (d Drift) String() string {
+	parts := []string{}
+	if len(d.Changed) > 0 {
+		parts = append(parts, "digest mismatch: "+strings.Join(d.Changed, ", "))
+	}
+	if len(d.Missing) > 0 {
+		parts = append(parts, "missing from bundle: "+strings.Join(d.Missing, ", "))
+	}
+	if len(d.Unlisted) > 0 {
+		parts = append(parts, "not in manifest: "+strings.Join(d.Unlisted, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Drift compare the file content to the manifest digests
+//
+// The manifest and signature files are not part of the comparison.
+// Files without a digest in the manifest can't be checked and are skipped
+func (b *Bundle) Drift() Drift {
+	drift := Drift{Changed: []string{}, Missing: []string{}, Unlisted: []string{}}
+	for label, descriptor := range b.manifest.Files {
+		if label == ManifestFilename {
+			continue
+		}
+		content, ok := b.content[label]
+		if !ok {
+			drift.Missing = append(drift.Missing, label)
+			continue
+		}
+		if descriptor.Digest == "" {
+			slog.Warn("no digest in bundle manifest, skip integrity check", "label", label)
+			continue
+		}
+		sum := sha256.Sum256(content)
+		if !strings.EqualFold(descriptor.Digest, hex.EncodeToString(sum[:])) {
+			drift.Changed = append(drift.Changed, label)
+		}
+	}
+	for label := range b.content {
+		if _, ok := b.manifest.Files[label]; !ok && label != ManifestFilename {
+			drift.Unlisted = append(drift.Unlisted, label)
+		}
+	}
+	sort.Strings(drift.Changed)
+	sort.Strings(drift.Missing)
+	sort.Strings(drift.Unlisted)
+	return drift
+}
+
+// UntarGzipBundle decode the bundle and check the content against the manifest digests
+//
+// The bundle is loaded even if the integrity check fails, the error wraps ErrIntegrity
+// and lists the labels that don't match, use bundle.Drift for the details
 func UntarGzipBundle(src io.Reader, bundle *Bundle) error {
 	gzipReader, err := gzip.NewReader(src)
 	if err != nil {
@@ -205,6 +356,7 @@ func UntarGzipBundle(src io.Reader, bundle *Bundle) error {
 	tarReader := tar.NewReader(gzipReader)
 
 	bundle.content = make(map[string][]byte)
+	bundle.signature = nil
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
@@ -218,6 +370,13 @@ func UntarGzipBundle(src io.Reader, bundle *Bundle) error {
 			return errors.New("gatecheck bundle only supports regular files in a flat directory structure")
 		}
 		fileBytes, _ := io.ReadAll(tarReader)
+		if header.Name == SignatureFilename {
+			bundle.signature = new(Signature)
+			if err := json.Unmarshal(fileBytes, bundle.signature); err != nil {
+				return fmt.Errorf("gatecheck signature decoding: %w", err)
+			}
+			continue
+		}
 		bundle.content[header.Name] = fileBytes
 	}
 	manifest := new(Manifest)
@@ -230,5 +389,9 @@ func UntarGzipBundle(src io.Reader, bundle *Bundle) error {
 	}
 	bundle.manifest = *manifest
 
+	if drift := bundle.Drift(); !drift.Empty() {
+		return fmt.Errorf("%w: %s", ErrIntegrity, drift)
+	}
+
 	return nil
 }
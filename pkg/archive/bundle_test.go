@@ -1,9 +1,13 @@
 package archive
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -57,3 +61,95 @@ func MustOpen(filename string, t *testing.T) *os.File {
 	}
 	return f
 }
+
+func TestUntarGzipBundle_integrity(t *testing.T) {
+	bundle := NewBundle()
+	bundle.Add([]byte("ABCDEF"), "file-1.txt", nil)
+	bundle.Add([]byte("GHIJKL"), "file-2.txt", nil)
+	bundle.Add([]byte("MNOPQR"), "file-3.txt", nil)
+
+	t.Run("success", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if _, err := TarGzipBundle(buf, bundle); err != nil {
+			t.Fatal(err)
+		}
+		if err := UntarGzipBundle(buf, NewBundle()); err != nil {
+			t.Fatalf("want: no error got: %v", err)
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		manifestBytes, _ := json.Marshal(bundle.Manifest())
+		files := map[string]string{
+			ManifestFilename: string(manifestBytes),
+			"file-1.txt":     "ABCDEF",
+			"file-2.txt":     "changed",
+			"file-4.txt":     "STUVWX",
+		}
+		buf := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for name, content := range files {
+			_ = tarWriter.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0o666})
+			_, _ = tarWriter.Write([]byte(content))
+		}
+		_ = tarWriter.Close()
+		_ = gzipWriter.Close()
+
+		decoded := NewBundle()
+		err := UntarGzipBundle(buf, decoded)
+		if !errors.Is(err, ErrIntegrity) {
+			t.Fatalf("want: %v got: %v", ErrIntegrity, err)
+		}
+		for _, label := range []string{"file-2.txt", "file-3.txt", "file-4.txt"} {
+			if !strings.Contains(err.Error(), label) {
+				t.Fatalf("want: %s in error got: %v", label, err)
+			}
+		}
+
+		drift := decoded.Drift()
+		if !slices.Equal(drift.Changed, []string{"file-2.txt"}) ||
+			!slices.Equal(drift.Missing, []string{"file-3.txt"}) ||
+			!slices.Equal(drift.Unlisted, []string{"file-4.txt"}) {
+			t.Fatalf("want: changed file-2.txt missing file-3.txt unlisted file-4.txt got: %+v", drift)
+		}
+	})
+}
+
+func TestBundle_Select(t *testing.T) {
+	bundle := NewBundle()
+	bundle.Add([]byte("{}"), "grype-report.json", []string{"image=api"})
+	bundle.Add([]byte("{}"), "trivy-report.json", []string{"image=web"})
+	bundle.Add([]byte("{}"), "gitleaks-report.json", []string{"image=api", "secrets"})
+
+	testTable := []struct {
+		label    string
+		patterns []string
+		tags     []string
+		want     []string
+	}{
+		{label: "all", want: []string{"gitleaks-report.json", "grype-report.json", "trivy-report.json"}},
+		{label: "exact", patterns: []string{"trivy-report.json"}, want: []string{"trivy-report.json"}},
+		{label: "glob", patterns: []string{"g*.json"}, want: []string{"gitleaks-report.json", "grype-report.json"}},
+		{label: "tag", tags: []string{"image=api"}, want: []string{"gitleaks-report.json", "grype-report.json"}},
+		{label: "every-tag", tags: []string{"image=api", "secrets"}, want: []string{"gitleaks-report.json"}},
+		{label: "glob-and-tag", patterns: []string{"*y-report.json"}, tags: []string{"image=web"}, want: []string{"trivy-report.json"}},
+		{label: "no-match", patterns: []string{"semgrep*"}, want: []string{}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			got, err := bundle.Select(testCase.patterns, testCase.tags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(testCase.want, got) {
+				t.Fatalf("want: %v got: %v", testCase.want, got)
+			}
+		})
+	}
+
+	if _, err := bundle.Select([]string{"["}, nil); err == nil {
+		t.Fatal("want: invalid pattern error got: nil")
+	}
+}
@@ -0,0 +1,219 @@
+package archive
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SignatureFilename the file in the bundle with the manifest signature
+const SignatureFilename = "gatecheck-signature.json"
+
+// Signature algorithms
+const (
+	AlgorithmEd25519     = "ed25519"
+	AlgorithmECDSASHA256 = "ecdsa-sha256"
+)
+
+// ErrUnsigned the bundle has no signature
+var ErrUnsigned = errors.New("gatecheck bundle is not signed")
+
+// ErrSignatureInvalid the signature doesn't match the manifest or the key
+var ErrSignatureInvalid = errors.New("gatecheck bundle signature is invalid")
+
+// Signature over the canonical manifest, stored in the bundle next to the manifest
+type Signature struct {
+	Algorithm string    `json:"algorithm"`
+	KeyID     string    `json:"keyId"`
+	SignedAt  time.Time `json:"signedAt"`
+	// Value the base64 encoded signature
+	Value string `json:"signature"`
+}
+
+// Canonical the manifest encoding that is signed
+//
+// Files are sorted by label, the manifest file itself is never included
+func (m Manifest) Canonical() ([]byte, error) {
+	files := make(map[string]fileDescriptor, len(m.Files))
+	for label, descriptor := range m.Files {
+		if label == ManifestFilename {
+			continue
+		}
+		files[label] = descriptor
+	}
+	m.Files = files
+	// encoding/json writes struct fields in order and map keys sorted
+	return json.Marshal(m)
+}
+
+// Signature the bundle signature, nil if the bundle is not signed
+func (b *Bundle) Signature() *Signature {
+	return b.signature
+}
+
+// Sign the canonical manifest with an ed25519 or ECDSA private key
+//
+// Adding or removing files drops the signature, sign after the last change
+func (b *Bundle) Sign(key crypto.Signer) error {
+	algorithm, err := signatureAlgorithm(key.Public())
+	if err != nil {
+		return err
+	}
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return err
+	}
+	message, err := b.manifest.Canonical()
+	if err != nil {
+		return err
+	}
+
+	var value []byte
+	switch algorithm {
+	case AlgorithmEd25519:
+		value, err = key.Sign(rand.Reader, message, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(message)
+		value, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return fmt.Errorf("gatecheck bundle signing: %w", err)
+	}
+
+	b.signature = &Signature{
+		Algorithm: algorithm,
+		KeyID:     keyID,
+		SignedAt:  time.Now(),
+		Value:     base64.StdEncoding.EncodeToString(value),
+	}
+	return nil
+}
+
+// Verify the signature with the public key of the signer
+//
+// Returns ErrUnsigned if there is no signature and ErrSignatureInvalid if the
+// manifest was changed after signing, the bundle was signed by a different key
+// or the file content doesn't match the signed manifest digests
+func (b *Bundle) Verify(publicKey crypto.PublicKey) error {
+	if b.signature == nil {
+		return ErrUnsigned
+	}
+	keyID, err := KeyID(publicKey)
+	if err != nil {
+		return err
+	}
+	if keyID != b.signature.KeyID {
+		return fmt.Errorf("%w: signed by key %s, want key %s", ErrSignatureInvalid, b.signature.KeyID, keyID)
+	}
+	value, err := base64.StdEncoding.DecodeString(b.signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: signature decoding: %v", ErrSignatureInvalid, err)
+	}
+	message, err := b.manifest.Canonical()
+	if err != nil {
+		return err
+	}
+
+	valid := false
+	switch pub := publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = b.signature.Algorithm == AlgorithmEd25519 && ed25519.Verify(pub, message, value)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = b.signature.Algorithm == AlgorithmECDSASHA256 && ecdsa.VerifyASN1(pub, digest[:], value)
+	}
+	if !valid {
+		return fmt.Errorf("%w: the manifest does not match the signature", ErrSignatureInvalid)
+	}
+	// the signature only covers the manifest, the content is covered by its digests
+	if drift := b.Drift(); !drift.Empty() {
+		return fmt.Errorf("%w: the content does not match the signed manifest, %s", ErrSignatureInvalid, drift)
+	}
+	return nil
+}
+
+// KeyID the hex encoded SHA-256 of the PKIX public key
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("unsupported public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ParsePrivateKey a PEM encoded PKCS #8 ed25519 or ECDSA key, or a SEC 1 ECDSA key
+func ParsePrivateKey(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("private key: no PEM data found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("private key: unsupported PEM type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("private key: unsupported key type %T, must be ed25519 or ECDSA", key)
+}
+
+// ParsePublicKey a PEM encoded PKIX ed25519 or ECDSA public key
+//
+// The public key is derived if a private key is passed
+func ParsePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("public key: no PEM data found")
+	}
+	if block.Type != "PUBLIC KEY" {
+		key, err := ParsePrivateKey(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		return key.Public(), nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	if _, err := signatureAlgorithm(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func signatureAlgorithm(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	case *ecdsa.PublicKey:
+		return AlgorithmECDSASHA256, nil
+	}
+	return "", fmt.Errorf("unsupported key type %T, must be ed25519 or ECDSA", publicKey)
+}
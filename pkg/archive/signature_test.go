@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func TestBundle_Sign(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	testTable := []struct {
+		label string
+		key   crypto.Signer
+	}{
+		{label: "ed25519", key: edKey},
+		{label: "ecdsa", key: ecKey},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			bundle := NewBundle()
+			bundle.Add([]byte("ABCDEF"), "file-1.txt", []string{"a"})
+			if err := bundle.Sign(testCase.key); err != nil {
+				t.Fatal(err)
+			}
+
+			// the signature is kept in the tarball
+			buf := new(bytes.Buffer)
+			if _, err := TarGzipBundle(buf, bundle); err != nil {
+				t.Fatal(err)
+			}
+			decoded := NewBundle()
+			if err := UntarGzipBundle(buf, decoded); err != nil {
+				t.Fatal(err)
+			}
+			if err := decoded.Verify(testCase.key.Public()); err != nil {
+				t.Fatalf("want: valid signature got: %v", err)
+			}
+
+			otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err := decoded.Verify(otherKey.Public()); !errors.Is(err, ErrSignatureInvalid) {
+				t.Fatalf("want: %v got: %v", ErrSignatureInvalid, err)
+			}
+
+			content := decoded.content["file-1.txt"]
+			decoded.content["file-1.txt"] = []byte("tampered")
+			if err := decoded.Verify(testCase.key.Public()); !errors.Is(err, ErrSignatureInvalid) {
+				t.Fatalf("tampered content want: %v got: %v", ErrSignatureInvalid, err)
+			}
+			decoded.content["file-1.txt"] = content
+
+			descriptor := decoded.manifest.Files["file-1.txt"]
+			descriptor.Tags = []string{"b"}
+			decoded.manifest.Files["file-1.txt"] = descriptor
+			if err := decoded.Verify(testCase.key.Public()); !errors.Is(err, ErrSignatureInvalid) {
+				t.Fatalf("tampered manifest want: %v got: %v", ErrSignatureInvalid, err)
+			}
+
+			decoded.Add([]byte("GHIJKL"), "file-2.txt", nil)
+			if err := decoded.Verify(testCase.key.Public()); !errors.Is(err, ErrUnsigned) {
+				t.Fatalf("changed bundle want: %v got: %v", ErrUnsigned, err)
+			}
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	edPublic, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	sec1, _ := x509.MarshalECPrivateKey(ecKey)
+	pkix, _ := x509.MarshalPKIXPublicKey(edPublic)
+
+	testTable := []struct {
+		label   string
+		block   *pem.Block
+		want    crypto.PublicKey
+		wantErr bool
+	}{
+		{label: "pkix", block: &pem.Block{Type: "PUBLIC KEY", Bytes: pkix}, want: edPublic},
+		{label: "pkcs8-private", block: &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}, want: edPublic},
+		{label: "sec1-private", block: &pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}, want: ecKey.Public()},
+		{label: "unsupported", block: &pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte{1}}, wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			got, err := ParsePublicKey(pem.EncodeToMemory(testCase.block))
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("want: error got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantID, _ := KeyID(testCase.want)
+			gotID, _ := KeyID(got)
+			if wantID != gotID {
+				t.Fatalf("want: %s got: %s", wantID, gotID)
+			}
+		})
+	}
+
+	if _, err := ParsePublicKey([]byte("not a key")); err == nil {
+		t.Fatal("want: error for non-PEM data got: nil")
+	}
+}
@@ -0,0 +1,46 @@
+package artifacts
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// CVSSScore a CVSS base score reported for a vulnerability
+type CVSSScore struct {
+	// Version the CVSS version, ex. 2.0, 3.0, 3.1, 4.0
+	Version   string  `json:"version"`
+	BaseScore float64 `json:"baseScore"`
+	Vector    string  `json:"vector,omitempty"`
+}
+
+// SelectCVSSScore the score to evaluate for a vulnerability
+//
+// Scores for the preferred version are used if there are any, ex. "3" prefers 3.0 or 3.1,
+// otherwise the scores for the highest version. When several sources score the same version
+// the highest score is returned. False if there are no scores
+func SelectCVSSScore(scores []CVSSScore, preferredVersion string) (CVSSScore, bool) {
+	if len(scores) == 0 {
+		return CVSSScore{}, false
+	}
+
+	candidates := slices.DeleteFunc(slices.Clone(scores), func(score CVSSScore) bool {
+		return preferredVersion == "" || !strings.HasPrefix(score.Version, preferredVersion)
+	})
+	if len(candidates) == 0 {
+		candidates = scores
+	}
+
+	return slices.MaxFunc(candidates, func(a, b CVSSScore) int {
+		return cmp.Or(cmp.Compare(a.Version, b.Version), cmp.Compare(a.BaseScore, b.BaseScore))
+	}), true
+}
+
+// cvssVersionFromVector the version in a CVSS v3 or v4 vector, ex. CVSS:3.1/AV:N/...
+func cvssVersionFromVector(vector string, defaultVersion string) string {
+	prefix, _, found := strings.Cut(vector, "/")
+	if version, ok := strings.CutPrefix(prefix, "CVSS:"); found && ok {
+		return version
+	}
+	return defaultVersion
+}
@@ -16,20 +16,44 @@ type CyclonedxReportMin struct {
 }
 
 type CyclonedxComponent struct {
-	BOMRef  string `json:"bom-ref"`
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	BOMRef   string                   `json:"bom-ref"`
+	Type     string                   `json:"type"`
+	Name     string                   `json:"name"`
+	Version  string                   `json:"version"`
+	PURL     string                   `json:"purl"`
+	Licenses []CyclonedxLicenseChoice `json:"licenses"`
+}
+
+// CyclonedxLicenseChoice either a single license or an SPDX license expression
+type CyclonedxLicenseChoice struct {
+	License    CyclonedxLicense `json:"license"`
+	Expression string           `json:"expression"`
+}
+
+// CyclonedxLicense a license by SPDX ID or by name if the license is not in the SPDX list
+type CyclonedxLicense struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type CyclonedxVulnerability struct {
-	ID         string                     `json:"id"`
-	Advisories []CyclonedxAdvisory        `json:"advisories"`
-	Affects    []CyclondexAffectedPackage `json:"affects"`
-	Ratings    []CyclonedxRating          `json:"ratings"`
+	ID             string                     `json:"id"`
+	Advisories     []CyclonedxAdvisory        `json:"advisories"`
+	Affects        []CyclondexAffectedPackage `json:"affects"`
+	Ratings        []CyclonedxRating          `json:"ratings"`
+	Recommendation string                     `json:"recommendation"`
 }
 
 type CyclondexAffectedPackage struct {
-	Ref string `json:"ref"`
+	Ref      string                     `json:"ref"`
+	Versions []CyclonedxAffectedVersion `json:"versions"`
+}
+
+// CyclonedxAffectedVersion a version or range of the package with a status of affected, unaffected or unknown
+type CyclonedxAffectedVersion struct {
+	Version string `json:"version"`
+	Range   string `json:"range"`
+	Status  string `json:"status"`
 }
 
 type CyclonedxAdvisory struct {
@@ -39,12 +63,51 @@ type CyclonedxAdvisory struct {
 type CyclonedxRating struct {
 	Source   CyclonedxSource `json:"source"`
 	Severity string          `json:"severity"`
+	Score    float64         `json:"score"`
+	// Method the scoring method, ex. CVSSv31, CVSSv4, OWASP
+	Method string `json:"method"`
+	Vector string `json:"vector"`
+}
+
+// cyclonedxCVSSVersions the CVSS version for each CVSS rating method
+var cyclonedxCVSSVersions = map[string]string{
+	"CVSSv2":  "2.0",
+	"CVSSv3":  "3.0",
+	"CVSSv31": "3.1",
+	"CVSSv4":  "4.0",
 }
 
 type CyclonedxSource struct {
 	Name string `json:"name"`
 }
 
+// LicenseIDs the SPDX ID, name or expression of each license on the component
+func (c *CyclonedxComponent) LicenseIDs() []string {
+	ids := []string{}
+	for _, choice := range c.Licenses {
+		id := cmp.Or(choice.License.ID, choice.License.Name, choice.Expression)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SBOMPackages the components as SBOM packages
+func (r *CyclonedxReportMin) SBOMPackages() []SBOMPackage {
+	packages := make([]SBOMPackage, 0, len(r.Components))
+	for _, component := range r.Components {
+		packages = append(packages, SBOMPackage{
+			Name:     component.Name,
+			Version:  component.Version,
+			Type:     component.Type,
+			PURL:     component.PURL,
+			Licenses: component.LicenseIDs(),
+		})
+	}
+	return packages
+}
+
 func (r *CyclonedxReportMin) SelectBySeverity(severity string) []CyclonedxVulnerability {
 	vulnerabilities := []CyclonedxVulnerability{}
 
@@ -56,6 +119,40 @@ func (r *CyclonedxReportMin) SelectBySeverity(severity string) []CyclonedxVulner
 	return vulnerabilities
 }
 
+// FixedIn the unaffected versions of the affected packages, or the recommendation if there aren't any
+func (r *CyclonedxVulnerability) FixedIn() string {
+	versions := []string{}
+	for _, affected := range r.Affects {
+		for _, version := range affected.Versions {
+			if strings.EqualFold(version.Status, "unaffected") {
+				versions = append(versions, cmp.Or(version.Version, version.Range))
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return r.Recommendation
+	}
+	return strings.Join(versions, ", ")
+}
+
+// CVSSScores the scores from the CVSS ratings, other rating methods are ignored
+func (r *CyclonedxVulnerability) CVSSScores() []CVSSScore {
+	scores := []CVSSScore{}
+	for _, rating := range r.Ratings {
+		version, ok := cyclonedxCVSSVersions[rating.Method]
+		if !ok || rating.Score == 0 {
+			continue
+		}
+		scores = append(scores, CVSSScore{Version: version, BaseScore: rating.Score, Vector: rating.Vector})
+	}
+	return scores
+}
+
+// Fixable true if there is an unaffected version or a recommendation
+func (r *CyclonedxVulnerability) Fixable() bool {
+	return r.FixedIn() != ""
+}
+
 func (r *CyclonedxVulnerability) HighestSeverity() string {
 	order := map[string]int{"none": 0, "low": 1, "medium": 2, "high": 3, "critical": 4}
 	rating := slices.MaxFunc(r.Ratings, func(a, b CyclonedxRating) int {
@@ -64,22 +161,24 @@ func (r *CyclonedxVulnerability) HighestSeverity() string {
 	return rating.Severity
 }
 
-func (r CyclonedxReportMin) AffectedPackages(vulnerabilityIndex int) string {
-	refs := []string{}
-
+// AffectedComponents the components in the sbom linked to the vulnerability
+func (r CyclonedxReportMin) AffectedComponents(vulnerabilityIndex int) []CyclonedxComponent {
+	components := []CyclonedxComponent{}
 	for _, affected := range r.Vulnerabilities[vulnerabilityIndex].Affects {
-		refs = append(refs, affected.Ref)
-	}
-
-	pkgs := []string{}
-	// The components in the sbom are linked to affected vulnerabilities
-	for _, ref := range refs {
 		for _, component := range r.Components {
-			if ref == component.BOMRef {
-				pkgs = append(pkgs, fmt.Sprintf("%s [%s]", component.Name, component.Version))
+			if affected.Ref == component.BOMRef {
+				components = append(components, component)
 			}
 		}
 	}
+	return components
+}
+
+func (r CyclonedxReportMin) AffectedPackages(vulnerabilityIndex int) string {
+	pkgs := []string{}
+	for _, component := range r.AffectedComponents(vulnerabilityIndex) {
+		pkgs = append(pkgs, fmt.Sprintf("%s [%s]", component.Name, component.Version))
+	}
 
 	return strings.Join(pkgs, ", ")
 }
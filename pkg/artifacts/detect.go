@@ -0,0 +1,162 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Artifact types that can be detected from a filename or the content of a report
+const (
+	TypeGrype     = "grype"
+	TypeCyclonedx = "cyclonedx"
+	TypeTrivy     = "trivy"
+	TypeSyft      = "syft"
+	TypeSemgrep   = "semgrep"
+	TypeGitleaks  = "gitleaks"
+	TypeSarif     = "sarif"
+	TypeBundle    = "bundle"
+	TypeLCOV      = "lcov"
+	TypeCobertura = "cobertura"
+	TypeClover    = "clover"
+)
+
+var ErrUnknownType = errors.New("unknown artifact type")
+
+// filenameTypes the keywords in a filename for each artifact type
+var filenameTypes = map[string][]string{
+	TypeGrype:     {"grype"},
+	TypeCyclonedx: {"cyclonedx"},
+	TypeTrivy:     {"trivy"},
+	TypeSyft:      {"syft"},
+	TypeSemgrep:   {"semgrep"},
+	TypeGitleaks:  {"gitleaks"},
+	TypeSarif:     {"sarif"},
+	TypeBundle:    {"bundle", "gatecheck"},
+}
+
+// IsCoverageType true for the code coverage artifact types
+func IsCoverageType(artifactType string) bool {
+	return slices.Contains([]string{TypeLCOV, TypeCobertura, TypeClover}, artifactType)
+}
+
+// TypeFromFilename the artifact type named in the filename
+//
+// An empty string is returned if the filename doesn't name a type
+// or if it names more than one, ex. grype-semgrep.json
+func TypeFromFilename(filename string) string {
+	matched := []string{}
+	for artifactType, keywords := range filenameTypes {
+		if slices.ContainsFunc(keywords, func(keyword string) bool { return strings.Contains(filename, keyword) }) {
+			matched = append(matched, artifactType)
+		}
+	}
+
+	if IsCoverageReport(filename) {
+		if mode, err := coverageModeFromFilename(filename); err == nil {
+			matched = append(matched, string(mode))
+		}
+	}
+
+	if len(matched) != 1 {
+		return ""
+	}
+	return matched[0]
+}
+
+// Detect the artifact type from the structure of the content
+//
+// JSON reports are identified by their top level fields, gitleaks reports are JSON arrays,
+// bundles are gzip compressed and coverage reports by their text or XML format
+func Detect(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	content = bytes.TrimSpace(content)
+
+	switch {
+	case len(content) == 0:
+		return "", ErrUnknownType
+	case bytes.HasPrefix(content, []byte{0x1f, 0x8b}):
+		return TypeBundle, nil
+	case content[0] == '[':
+		return detectJSONArray(content)
+	case content[0] == '{':
+		return detectJSONObject(content)
+	case content[0] == '<':
+		return detectXML(content)
+	case bytes.HasPrefix(content, []byte("TN:")) || bytes.HasPrefix(content, []byte("SF:")):
+		return TypeLCOV, nil
+	}
+
+	return "", ErrUnknownType
+}
+
+func detectJSONArray(content []byte) (string, error) {
+	findings := []map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &findings); err != nil {
+		return "", ErrUnknownType
+	}
+	if len(findings) == 0 {
+		return TypeGitleaks, nil
+	}
+	if _, ok := findings[0]["RuleID"]; ok {
+		return TypeGitleaks, nil
+	}
+	return "", ErrUnknownType
+}
+
+func detectJSONObject(content []byte) (string, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return "", ErrUnknownType
+	}
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := fields[key]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	// grype and syft both have a descriptor with the name of the tool
+	descriptor := struct {
+		Name string `json:"name"`
+	}{}
+	if has("descriptor") {
+		_ = json.Unmarshal(fields["descriptor"], &descriptor)
+	}
+
+	switch {
+	case has("bomFormat"):
+		return TypeCyclonedx, nil
+	case has("SchemaVersion", "Results"):
+		return TypeTrivy, nil
+	case has("runs", "version"):
+		return TypeSarif, nil
+	case descriptor.Name == "grype" || has("descriptor", "matches"):
+		return TypeGrype, nil
+	case descriptor.Name == "syft" || has("descriptor", "artifacts"):
+		return TypeSyft, nil
+	case has("results", "version") || has("results", "errors"):
+		return TypeSemgrep, nil
+	}
+	return "", ErrUnknownType
+}
+
+func detectXML(content []byte) (string, error) {
+	idx := bytes.Index(content, []byte("<coverage"))
+	if idx == -1 {
+		return "", ErrUnknownType
+	}
+	// clover reports nest a project element in the coverage element
+	if bytes.Contains(content[idx:], []byte("<project")) {
+		return TypeClover, nil
+	}
+	return TypeCobertura, nil
+}
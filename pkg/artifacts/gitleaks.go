@@ -15,10 +15,11 @@ func (r *GitLeaksReportMin) Count() int {
 }
 
 type GitleaksFinding struct {
-	RuleID    string `json:"RuleID"`
-	File      string `json:"File"`
-	Commit    string `json:"Commit"`
-	StartLine int    `json:"StartLine"`
+	RuleID      string `json:"RuleID"`
+	File        string `json:"File"`
+	Commit      string `json:"Commit"`
+	StartLine   int    `json:"StartLine"`
+	Fingerprint string `json:"Fingerprint"`
 }
 
 func (f *GitleaksFinding) FileShort() string {
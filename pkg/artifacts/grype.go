@@ -23,12 +23,63 @@ type GrypeDescriptor struct {
 type GrypeArtifact struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
+	// Type is the package ecosystem, ex. deb, npm, go-module
+	Type      string          `json:"type"`
+	PURL      string          `json:"purl"`
+	Locations []GrypeLocation `json:"locations"`
+}
+
+// GrypeLocation where the package was found in the scanned image or directory
+type GrypeLocation struct {
+	Path    string `json:"path"`
+	LayerID string `json:"layerID"`
 }
 
 type GrypeVulnerability struct {
-	ID         string `json:"id"`
-	Severity   string `json:"severity"`
-	DataSource string `json:"dataSource"`
+	ID         string      `json:"id"`
+	Severity   string      `json:"severity"`
+	DataSource string      `json:"dataSource"`
+	Fix        GrypeFix    `json:"fix"`
+	CVSS       []GrypeCVSS `json:"cvss"`
+}
+
+type GrypeCVSS struct {
+	Source  string           `json:"source"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Vector  string           `json:"vector"`
+	Metrics GrypeCVSSMetrics `json:"metrics"`
+}
+
+type GrypeCVSSMetrics struct {
+	BaseScore float64 `json:"baseScore"`
+}
+
+// CVSSScores the base score for each reported CVSS version
+func (v GrypeVulnerability) CVSSScores() []CVSSScore {
+	scores := make([]CVSSScore, 0, len(v.CVSS))
+	for _, cvss := range v.CVSS {
+		scores = append(scores, CVSSScore{Version: cvss.Version, BaseScore: cvss.Metrics.BaseScore, Vector: cvss.Vector})
+	}
+	return scores
+}
+
+// GrypeFix the fix status of a vulnerability
+//
+// State is fixed, not-fixed, wont-fix or unknown
+type GrypeFix struct {
+	Versions []string `json:"versions"`
+	State    string   `json:"state"`
+}
+
+// Fixable true if a fixed version is available
+func (v GrypeVulnerability) Fixable() bool {
+	return strings.EqualFold(v.Fix.State, "fixed")
+}
+
+// FixedIn the fixed versions, empty if there isn't a fix
+func (v GrypeVulnerability) FixedIn() string {
+	return strings.Join(v.Fix.Versions, ", ")
 }
 
 func (g *GrypeReportMin) SelectBySeverity(severity string) []GrypeMatch {
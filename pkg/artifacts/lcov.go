@@ -17,16 +17,22 @@ func IsCoverageReport(inputFilename string) bool {
 }
 
 func GetCoverageMode(inputFilename string) (coverage.CoverageMode, error) {
-	var coverageFormat coverage.CoverageMode
-	if strings.Contains(inputFilename, "lcov") || strings.HasSuffix(inputFilename, ".info") {
-		coverageFormat = coverage.LCOV
-	} else if strings.Contains(inputFilename, "clover") {
-		coverageFormat = coverage.CLOVER
-	} else if strings.HasSuffix(inputFilename, ".xml") {
-		coverageFormat = coverage.COBERTURA
-	} else {
+	coverageFormat, err := coverageModeFromFilename(inputFilename)
+	if err != nil {
 		slog.Error("unsupported coverage file type, cannot be determined from filename", "filename", inputFilename)
 		return "", errors.New("failed to list coverage content")
 	}
 	return coverageFormat, nil
 }
+
+func coverageModeFromFilename(inputFilename string) (coverage.CoverageMode, error) {
+	switch {
+	case strings.Contains(inputFilename, "lcov") || strings.HasSuffix(inputFilename, ".info"):
+		return coverage.LCOV, nil
+	case strings.Contains(inputFilename, "clover"):
+		return coverage.CLOVER, nil
+	case strings.HasSuffix(inputFilename, ".xml"):
+		return coverage.COBERTURA, nil
+	}
+	return "", ErrUnknownType
+}
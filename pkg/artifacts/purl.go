@@ -0,0 +1,15 @@
+package artifacts
+
+import "strings"
+
+// PURLType the package type of a package URL, ex. npm for pkg:npm/lodash@4.17.21
+//
+// An empty string is returned if the value isn't a package URL
+func PURLType(purl string) string {
+	rest, found := strings.CutPrefix(purl, "pkg:")
+	if !found {
+		return ""
+	}
+	purlType, _, _ := strings.Cut(rest, "/")
+	return strings.ToLower(purlType)
+}
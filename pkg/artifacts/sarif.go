@@ -0,0 +1,103 @@
+package artifacts
+
+import (
+	"slices"
+	"strings"
+)
+
+// SarifReportMin is a minimum representation of a SARIF 2.1.0 log
+//
+// It contains only the necessary fields for validation and listing
+type SarifReportMin struct {
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Rules   []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string                 `json:"id"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      SarifMessage       `json:"message"`
+	Locations    []SarifLocation    `json:"locations"`
+	Suppressions []SarifSuppression `json:"suppressions"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type SarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+// ResultLevel the level of a result
+//
+// SARIF allows the level to be omitted from the result,
+// in that case the rule default is used, and "warning" if neither are set
+func (r *SarifRun) ResultLevel(result SarifResult) string {
+	if result.Level != "" {
+		return strings.ToLower(result.Level)
+	}
+	idx := slices.IndexFunc(r.Tool.Driver.Rules, func(rule SarifRule) bool {
+		return rule.ID == result.RuleID
+	})
+	if idx != -1 && r.Tool.Driver.Rules[idx].DefaultConfiguration.Level != "" {
+		return strings.ToLower(r.Tool.Driver.Rules[idx].DefaultConfiguration.Level)
+	}
+	return "warning"
+}
+
+// IsSuppressed true if the tool reported the result as suppressed
+func (r *SarifResult) IsSuppressed() bool {
+	return len(r.Suppressions) > 0
+}
+
+// Location the uri and start line of the first location
+func (r *SarifResult) Location() (string, int) {
+	if len(r.Locations) == 0 {
+		return "", 0
+	}
+	location := r.Locations[0].PhysicalLocation
+	return location.ArtifactLocation.URI, location.Region.StartLine
+}
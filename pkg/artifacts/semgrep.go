@@ -18,8 +18,15 @@ type semgrepError struct {
 }
 
 type SemgrepResults struct {
-	Extra   SemgrepExtra `json:"extra"`
-	CheckID string       `json:"check_id"`
+	Extra   SemgrepExtra    `json:"extra"`
+	CheckID string          `json:"check_id"`
+	Path    string          `json:"path"`
+	Start   SemgrepPosition `json:"start"`
+}
+
+type SemgrepPosition struct {
+	Line int `json:"line"`
+	Col  int `json:"col"`
 }
 
 type SemgrepExtra struct {
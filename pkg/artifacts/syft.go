@@ -0,0 +1,81 @@
+package artifacts
+
+import "encoding/json"
+
+// SyftReportMin is a minimum representation of an Anchore Syft SBOM in the syft JSON format
+//
+// It contains only the necessary fields for validation and listing
+type SyftReportMin struct {
+	Artifacts  []SyftArtifact `json:"artifacts"`
+	Descriptor SyftDescriptor `json:"descriptor"`
+}
+
+type SyftArtifact struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Version  string       `json:"version"`
+	Type     string       `json:"type"`
+	PURL     string       `json:"purl"`
+	Licenses SyftLicenses `json:"licenses"`
+}
+
+// SyftLicenses the license values of an artifact
+//
+// Older syft schemas encode licenses as strings, newer schemas as objects
+// with the SPDX expression when one could be determined
+type SyftLicenses []string
+
+func (l *SyftLicenses) UnmarshalJSON(data []byte) error {
+	values := []string{}
+	if err := json.Unmarshal(data, &values); err == nil {
+		*l = values
+		return nil
+	}
+
+	objects := []struct {
+		Value          string `json:"value"`
+		SPDXExpression string `json:"spdxExpression"`
+	}{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return err
+	}
+	values = make([]string, 0, len(objects))
+	for _, object := range objects {
+		if object.SPDXExpression != "" {
+			values = append(values, object.SPDXExpression)
+			continue
+		}
+		values = append(values, object.Value)
+	}
+	*l = values
+	return nil
+}
+
+type SyftDescriptor struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// SBOMPackages the cataloged artifacts as SBOM packages
+func (r *SyftReportMin) SBOMPackages() []SBOMPackage {
+	packages := make([]SBOMPackage, 0, len(r.Artifacts))
+	for _, artifact := range r.Artifacts {
+		packages = append(packages, SBOMPackage{
+			Name:     artifact.Name,
+			Version:  artifact.Version,
+			Type:     artifact.Type,
+			PURL:     artifact.PURL,
+			Licenses: artifact.Licenses,
+		})
+	}
+	return packages
+}
+
+// SBOMPackage a single package from an SBOM independent of the SBOM format
+type SBOMPackage struct {
+	Name     string
+	Version  string
+	Type     string
+	PURL     string
+	Licenses []string
+}
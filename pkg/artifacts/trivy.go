@@ -0,0 +1,100 @@
+package artifacts
+
+import (
+	"slices"
+	"strings"
+)
+
+// TrivyReportMin is a minimum representation of an Aqua Security Trivy scan report
+//
+// It contains only the necessary fields for validation and listing
+type TrivyReportMin struct {
+	SchemaVersion int           `json:"SchemaVersion"`
+	ArtifactName  string        `json:"ArtifactName"`
+	ArtifactType  string        `json:"ArtifactType"`
+	Results       []TrivyResult `json:"Results"`
+}
+
+// TrivyResult the vulnerabilities found in a single scan target, like an OS or language package set
+type TrivyResult struct {
+	Target          string               `json:"Target"`
+	Class           string               `json:"Class"`
+	Type            string               `json:"Type"`
+	Vulnerabilities []TrivyVulnerability `json:"Vulnerabilities"`
+}
+
+type TrivyVulnerability struct {
+	VulnerabilityID  string             `json:"VulnerabilityID"`
+	PkgName          string             `json:"PkgName"`
+	InstalledVersion string             `json:"InstalledVersion"`
+	PkgIdentifier    TrivyPkgIdentifier `json:"PkgIdentifier"`
+	FixedVersion     string             `json:"FixedVersion"`
+	Status           string             `json:"Status"`
+	Severity         string             `json:"Severity"`
+	Title            string             `json:"Title"`
+	PrimaryURL       string             `json:"PrimaryURL"`
+	// CVSS the scores from each vendor, ex. nvd, redhat
+	CVSS map[string]TrivyCVSS `json:"CVSS"`
+}
+
+type TrivyCVSS struct {
+	V2Vector  string  `json:"V2Vector"`
+	V3Vector  string  `json:"V3Vector"`
+	V40Vector string  `json:"V40Vector"`
+	V2Score   float64 `json:"V2Score"`
+	V3Score   float64 `json:"V3Score"`
+	V40Score  float64 `json:"V40Score"`
+}
+
+// CVSSScores the scores from every vendor and CVSS version
+func (v TrivyVulnerability) CVSSScores() []CVSSScore {
+	scores := []CVSSScore{}
+	for _, cvss := range v.CVSS {
+		if cvss.V2Score > 0 {
+			scores = append(scores, CVSSScore{Version: "2.0", BaseScore: cvss.V2Score, Vector: cvss.V2Vector})
+		}
+		if cvss.V3Score > 0 {
+			scores = append(scores, CVSSScore{Version: cvssVersionFromVector(cvss.V3Vector, "3.0"), BaseScore: cvss.V3Score, Vector: cvss.V3Vector})
+		}
+		if cvss.V40Score > 0 {
+			scores = append(scores, CVSSScore{Version: "4.0", BaseScore: cvss.V40Score, Vector: cvss.V40Vector})
+		}
+	}
+	return scores
+}
+
+// Fixable true if trivy reports a fixed version
+func (v TrivyVulnerability) Fixable() bool {
+	return v.FixedVersion != ""
+}
+
+// TrivyPkgIdentifier the package URL of the vulnerable package
+type TrivyPkgIdentifier struct {
+	PURL string `json:"PURL"`
+}
+
+// Vulnerabilities every vulnerability across all of the scan targets
+func (r *TrivyReportMin) Vulnerabilities() []TrivyVulnerability {
+	vulnerabilities := []TrivyVulnerability{}
+	for _, result := range r.Results {
+		vulnerabilities = append(vulnerabilities, result.Vulnerabilities...)
+	}
+	return vulnerabilities
+}
+
+// DeleteVulnerabilitiesFunc removes the vulnerabilities where del returns true from every scan target
+func (r *TrivyReportMin) DeleteVulnerabilitiesFunc(del func(TrivyVulnerability) bool) {
+	for i := range r.Results {
+		r.Results[i].Vulnerabilities = slices.DeleteFunc(r.Results[i].Vulnerabilities, del)
+	}
+}
+
+func (r *TrivyReportMin) SelectBySeverity(severity string) []TrivyVulnerability {
+	vulnerabilities := []TrivyVulnerability{}
+	for _, vulnerability := range r.Vulnerabilities() {
+		if strings.EqualFold(vulnerability.Severity, severity) {
+			vulnerabilities = append(vulnerabilities, vulnerability)
+		}
+	}
+	return vulnerabilities
+}
@@ -0,0 +1,250 @@
+// Package cache keeps downloaded EPSS and KEV data on disk between runs
+//
+// The cache is an http.RoundTripper so the EPSS and KEV packages read through it
+// with their existing client option. Fresh entries are served from disk,
+// stale entries are revalidated with ETag and Last-Modified conditional requests
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirEnvKey overrides the default cache directory
+const DirEnvKey = "GATECHECK_CACHE_DIR"
+
+// DefaultMaxAge how long a cached response is used before it's revalidated
+const DefaultMaxAge = 24 * time.Hour
+
+// ErrOffline the data can't be requested in offline mode because the cache entry is missing or stale
+var ErrOffline = errors.New("offline mode")
+
+// now the current time used to evaluate the cache age, replaced in tests
+var now = time.Now
+
+// Cache settings for the on-disk cache
+type Cache struct {
+	// Dir the directory for cached responses, created on the first write
+	Dir string
+	// MaxAge responses older than this are revalidated, 0 always revalidates
+	MaxAge time.Duration
+	// Offline never make a request, a missing or stale entry is an error
+	Offline bool
+}
+
+// entry metadata stored next to the cached response body
+type entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+// DefaultDir the GATECHECK_CACHE_DIR environment variable or gatecheck in the user cache directory
+//
+// The user cache directory is $XDG_CACHE_HOME or ~/.cache on Linux
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(DirEnvKey); dir != "" {
+		return dir, nil
+	}
+	userDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the cache directory, set %s: %w", DirEnvKey, err)
+	}
+	return filepath.Join(userDir, "gatecheck"), nil
+}
+
+// Client an http client that reads through the cache
+//
+// Requests that miss the cache use the base client's transport
+func (c *Cache) Client(base *http.Client) *http.Client {
+	if base == nil {
+		base = http.DefaultClient
+	}
+	next := base.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport:     &transport{cache: c, next: next},
+		CheckRedirect: base.CheckRedirect,
+		Jar:           base.Jar,
+		Timeout:       base.Timeout,
+	}
+}
+
+type transport struct {
+	cache *Cache
+	next  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	url := req.URL.String()
+	key := t.cache.key(url)
+	logger := slog.Default().With("url", url, "cache_dir", t.cache.Dir)
+
+	cached, hasEntry := t.cache.readEntry(key)
+	age := now().Sub(cached.FetchedAt)
+
+	switch {
+	case hasEntry && age < t.cache.MaxAge:
+		logger.Debug("cache hit", "age", age.Round(time.Second))
+		return t.cache.response(req, key)
+	case t.cache.Offline && !hasEntry:
+		return nil, fmt.Errorf("%w: no cached data for %s in %s, run gatecheck download first", ErrOffline, url, t.cache.Dir)
+	case t.cache.Offline:
+		return nil, fmt.Errorf("%w: cached data for %s is stale, fetched %s ago with a max age of %s, run gatecheck download first",
+			ErrOffline, url, age.Round(time.Second), t.cache.MaxAge)
+	}
+
+	req = req.Clone(req.Context())
+	if hasEntry && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if hasEntry && cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && hasEntry:
+		_ = res.Body.Close()
+		logger.Debug("cache revalidated, not modified")
+		cached.FetchedAt = now()
+		if err := t.cache.writeEntry(key, cached); err != nil {
+			logger.Warn("cannot update cache entry", "error", err)
+		}
+		return t.cache.response(req, key)
+	case res.StatusCode != http.StatusOK:
+		return res, nil
+	}
+
+	content, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(content))
+
+	newEntry := entry{
+		URL:          url,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		FetchedAt:    now(),
+	}
+	if err := t.cache.write(key, newEntry, content); err != nil {
+		// the response is still usable, the next run will download it again
+		logger.Warn("cannot write cache entry", "error", err)
+		return res, nil
+	}
+	logger.Debug("cache updated", "etag", newEntry.ETag, "last_modified", newEntry.LastModified)
+	return res, nil
+}
+
+func (c *Cache) key(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (c *Cache) dataFilename(key string) string {
+	return filepath.Join(c.Dir, key+".data")
+}
+
+func (c *Cache) entryFilename(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+func (c *Cache) readEntry(key string) (entry, bool) {
+	e := entry{}
+	f, err := os.Open(c.entryFilename(key))
+	if err != nil {
+		return e, false
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&e); err != nil {
+		slog.Warn("invalid cache entry, ignored", "filename", c.entryFilename(key), "error", err)
+		return e, false
+	}
+	if _, err := os.Stat(c.dataFilename(key)); err != nil {
+		return e, false
+	}
+	return e, true
+}
+
+func (c *Cache) writeEntry(key string, e entry) error {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.entryFilename(key), content)
+}
+
+func (c *Cache) write(key string, e entry, content []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.dataFilename(key), content); err != nil {
+		return err
+	}
+	return c.writeEntry(key, e)
+}
+
+// response a 200 response with the cached body
+func (c *Cache) response(req *http.Request, key string) (*http.Response, error) {
+	f, err := os.Open(c.dataFilename(key))
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          f,
+		ContentLength: info.Size(),
+		Request:       req,
+	}, nil
+}
+
+// writeFileAtomic write to a temporary file and rename it so concurrent runs never read a partial file
+func writeFileAtomic(filename string, content []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
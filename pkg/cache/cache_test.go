@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCache_Client(t *testing.T) {
+	current := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+
+	requests := 0
+	notModified := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("data-v1"))
+	}))
+	defer server.Close()
+
+	c := &Cache{Dir: t.TempDir(), MaxAge: time.Hour}
+	get := func(t *testing.T) (string, error) {
+		res, err := c.Client(server.Client()).Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+		content, err := io.ReadAll(res.Body)
+		return string(content), err
+	}
+
+	t.Run("offline-missing", func(t *testing.T) {
+		c.Offline = true
+		defer func() { c.Offline = false }()
+		if _, err := get(t); !errors.Is(err, ErrOffline) {
+			t.Fatalf("want: %v got: %v", ErrOffline, err)
+		}
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		content, err := get(t)
+		if err != nil || content != "data-v1" || requests != 1 {
+			t.Fatalf("want: data-v1 after 1 request got: %q after %d requests, error: %v", content, requests, err)
+		}
+	})
+
+	t.Run("fresh", func(t *testing.T) {
+		current = current.Add(30 * time.Minute)
+		content, err := get(t)
+		if err != nil || content != "data-v1" || requests != 1 {
+			t.Fatalf("want: cached data-v1 got: %q after %d requests, error: %v", content, requests, err)
+		}
+	})
+
+	t.Run("offline-stale", func(t *testing.T) {
+		current = current.Add(2 * time.Hour)
+		c.Offline = true
+		defer func() { c.Offline = false }()
+		if _, err := get(t); !errors.Is(err, ErrOffline) {
+			t.Fatalf("want: %v got: %v", ErrOffline, err)
+		}
+	})
+
+	t.Run("revalidate", func(t *testing.T) {
+		content, err := get(t)
+		if err != nil || content != "data-v1" || notModified != 1 {
+			t.Fatalf("want: data-v1 from a not modified response got: %q with %d not modified, error: %v", content, notModified, err)
+		}
+		// the revalidated entry is fresh again
+		c.Offline = true
+		defer func() { c.Offline = false }()
+		if _, err := get(t); err != nil {
+			t.Fatalf("want: revalidated cache entry got: %v", err)
+		}
+	})
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv(DirEnvKey, "/tmp/gatecheck-cache")
+	dir, err := DefaultDir()
+	if err != nil || dir != "/tmp/gatecheck-cache" {
+		t.Fatalf("want: /tmp/gatecheck-cache got: %s error: %v", dir, err)
+	}
+}
@@ -19,7 +19,9 @@ import (
 const (
 	dataModel       = "v2025.03.14"
 	modelDateLayout = "2006-01-02T15:04:05Z"
-	defaultEPSSURL  = "https://epss.cyentia.com"
+	defaultEPSSURL  = "https://epss.cyentia.com/epss_scores-current.csv.gz"
+	// DateLayout the format of the score date in the dated file name, ex. epss_scores-2025-03-14.csv.gz
+	DateLayout = "2006-01-02"
 )
 
 // Data a representation of the CSV data from first API
@@ -75,10 +77,36 @@ func WithClient(client *http.Client) fetchOptionFunc {
 	}
 }
 
+// WithDate fetch the scores published on the date instead of the current scores
+//
+// Will fetch the current scores if a zero time is passed
+func WithDate(date time.Time) fetchOptionFunc {
+	return func(o *FetchOptions) {
+		o.Date = date
+	}
+}
+
 // FetchOptions optional settings for the request
 type FetchOptions struct {
 	Client *http.Client
 	URL    string
+	// Date the score date, zero for the current scores
+	Date time.Time
+}
+
+// ScoresURL the URL of the dated scores file in the same location as the current scores
+//
+// The URL is used as is for the current scores. For a date, the file name is replaced
+// if the URL is a .csv.gz file, otherwise the dated file name is appended to the URL
+func (o *FetchOptions) ScoresURL() string {
+	if o.Date.IsZero() {
+		return o.URL
+	}
+	baseURL := strings.TrimSuffix(o.URL, "/")
+	if strings.HasSuffix(baseURL, ".csv.gz") {
+		baseURL = baseURL[:strings.LastIndex(baseURL, "/")]
+	}
+	return fmt.Sprintf("%s/epss_scores-%s.csv.gz", baseURL, o.Date.Format(DateLayout))
 }
 
 // DefaultFetchOptions use the default client and url for today's scores
@@ -95,18 +123,20 @@ func DownloadData(w io.Writer, optionFuncs ...fetchOptionFunc) error {
 		optionFunc(options)
 	}
 
-	logger := slog.Default().With("method", "GET", "url", options.URL)
+	url := options.ScoresURL()
+	logger := slog.Default().With("method", "GET", "url", url)
 	defer func(started time.Time) {
 		logger.Debug("epss csv fetch done", "elapsed", time.Since(started))
 	}(time.Now())
 
 	logger.Debug("request epss data from api")
-	res, err := options.Client.Get(options.URL)
+	res, err := options.Client.Get(url)
 
 	switch {
 	case err != nil:
+		// the transport error is wrapped, ex. the caller checks for an offline cache miss
 		logger.Error("epss api request failed during fetch data", "error", err)
-		return errors.New("failed to get EPSS Scores. see log for details")
+		return fmt.Errorf("failed to get EPSS Scores: %w", err)
 	case res.StatusCode != http.StatusOK:
 		logger.Error("epss api bad status code", "res_status", res.Status)
 		return errors.New("failed to get EPSS Scores. see log for details")
@@ -137,7 +167,19 @@ func FetchData(destData *Data, optionFuncs ...fetchOptionFunc) error {
 		return err
 	}
 
-	return ParseEPSSDataCSV(buf, destData)
+	if err := ParseEPSSDataCSV(buf, destData); err != nil {
+		return err
+	}
+
+	options := DefaultFetchOptions()
+	for _, optionFunc := range optionFuncs {
+		optionFunc(options)
+	}
+	if !options.Date.IsZero() && destData.ScoreDate.Format(DateLayout) != options.Date.Format(DateLayout) {
+		slog.Warn("epss score date does not match the requested date",
+			"requested", options.Date.Format(DateLayout), "score_date", destData.ScoreDate.Format(DateLayout))
+	}
+	return nil
 }
 
 // ParseEPSSDataCSV custom CSV parsing function
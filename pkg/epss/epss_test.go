@@ -0,0 +1,26 @@
+package epss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFetchOptions_ScoresURL(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	testTable := []struct {
+		url  string
+		date time.Time
+		want string
+	}{
+		{url: defaultEPSSURL, want: defaultEPSSURL},
+		{url: defaultEPSSURL, date: date, want: "https://epss.cyentia.com/epss_scores-2024-03-01.csv.gz"},
+		{url: "https://mirror.example.com/epss/", date: date, want: "https://mirror.example.com/epss/epss_scores-2024-03-01.csv.gz"},
+	}
+
+	for _, testCase := range testTable {
+		options := &FetchOptions{URL: testCase.url, Date: testCase.date}
+		if got := options.ScoresURL(); got != testCase.want {
+			t.Fatalf("want: %s got: %s", testCase.want, got)
+		}
+	}
+}
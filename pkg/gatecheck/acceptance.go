@@ -0,0 +1,149 @@
+package gatecheck
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+const expiresLayout = "2006-01-02"
+
+// timeNow the current time used to evaluate risk acceptance expiration, replaced in tests
+var timeNow = time.Now
+
+// activeCVEAcceptances the risk acceptances that haven't expired
+//
+// Expired entries and entries with an invalid expiration date are logged and ignored.
+// Entries that expire within the warning window are returned as warnings
+func activeCVEAcceptances(acceptance configCVERiskAcceptance, artifact string) ([]configCVE, []string) {
+	year, month, day := timeNow().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	warningDate := today.AddDate(0, 0, int(acceptance.ExpirationWarningDays))
+
+	active := make([]configCVE, 0, len(acceptance.CVEs))
+	warnings := make([]string, 0)
+	for _, cve := range acceptance.CVEs {
+		if cve.Expires == "" {
+			active = append(active, cve)
+			continue
+		}
+
+		expires, err := time.Parse(expiresLayout, cve.Expires)
+		if err != nil {
+			slog.Error("invalid risk acceptance expiration date, acceptance ignored",
+				"artifact", artifact, "id", cve.ID, "expires", cve.Expires, "error", err)
+			warnings = append(warnings, fmt.Sprintf("%s acceptance ignored, invalid expiration date %q", cve.ID, cve.Expires))
+			continue
+		}
+
+		// acceptances are valid through the end of the expiration date
+		if expires.Before(today) {
+			slog.Warn("risk acceptance expired, acceptance ignored",
+				"artifact", artifact, "id", cve.ID, "expires", cve.Expires, "owner", cve.Owner)
+			warnings = append(warnings, fmt.Sprintf("%s acceptance expired on %s, owner: %s", cve.ID, cve.Expires, cve.Owner))
+			continue
+		}
+
+		if !expires.After(warningDate) {
+			slog.Warn("risk acceptance expires soon",
+				"artifact", artifact, "id", cve.ID, "expires", cve.Expires, "owner", cve.Owner)
+			warnings = append(warnings, fmt.Sprintf("%s acceptance expires on %s, owner: %s", cve.ID, cve.Expires, cve.Owner))
+		}
+		active = append(active, cve)
+	}
+	return active, warnings
+}
+
+// packageScope the fields of a vulnerable package that a CVE entry can be scoped to
+type packageScope struct {
+	Name    string
+	Version string
+	PURL    string
+	// Type the package type reported by the scanner, ex. go-module
+	Type string
+}
+
+// ecosystem the package type, from the purl if the scanner doesn't report it
+func (scope packageScope) ecosystem() string {
+	return cmp.Or(scope.Type, artifacts.PURLType(scope.PURL))
+}
+
+func grypeScope(artifact artifacts.GrypeArtifact) packageScope {
+	return packageScope{Name: artifact.Name, Version: artifact.Version, PURL: artifact.PURL, Type: artifact.Type}
+}
+
+func cyclonedxScope(component artifacts.CyclonedxComponent) packageScope {
+	return packageScope{Name: component.Name, Version: component.Version, PURL: component.PURL}
+}
+
+func trivyScope(vulnerability artifacts.TrivyVulnerability) packageScope {
+	return packageScope{Name: vulnerability.PkgName, Version: vulnerability.InstalledVersion, PURL: vulnerability.PkgIdentifier.PURL}
+}
+
+// cyclonedxScopes the scope for every component affected by the vulnerability
+//
+// A vulnerability without affected components has a single empty scope,
+// so only unscoped entries match it
+func cyclonedxScopes(report *artifacts.CyclonedxReportMin, vulnerabilityIdx int) []packageScope {
+	scopes := make([]packageScope, 0)
+	for _, component := range report.AffectedComponents(vulnerabilityIdx) {
+		scopes = append(scopes, cyclonedxScope(component))
+	}
+	if len(scopes) == 0 {
+		scopes = append(scopes, packageScope{})
+	}
+	return scopes
+}
+
+// matchesCVE true if the entry covers the vulnerability in the package
+func matchesCVE(cve configCVE, id string, scope packageScope) bool {
+	switch {
+	case !strings.EqualFold(cve.ID, id):
+		return false
+	case cve.Package != "" && !strings.EqualFold(cve.Package, scope.Name):
+		return false
+	case cve.Version != "" && cve.Version != scope.Version:
+		return false
+	case cve.VersionRange != "" && (scope.Version == "" || !versionInRange(scope.Version, cve.VersionRange, scope.ecosystem())):
+		return false
+	case cve.PURL != "" && !matchesPURL(cve.PURL, scope.PURL):
+		return false
+	case cve.Ecosystem != "" && !strings.EqualFold(cve.Ecosystem, scope.Type) && !strings.EqualFold(cve.Ecosystem, artifacts.PURLType(scope.PURL)):
+		return false
+	}
+	return true
+}
+
+// matchesPURL compares package URLs without qualifiers or subpath,
+// the version is ignored if the pattern doesn't have one
+func matchesPURL(pattern string, purl string) bool {
+	trim := func(s string) string {
+		s, _, _ = strings.Cut(s, "#")
+		s, _, _ = strings.Cut(s, "?")
+		return s
+	}
+	pattern, purl = trim(pattern), trim(purl)
+	if !strings.Contains(pattern, "@") {
+		purl, _, _ = strings.Cut(purl, "@")
+	}
+	return purl != "" && strings.EqualFold(pattern, purl)
+}
+
+// acceptanceDetail the justification recorded with an accepted finding
+func acceptanceDetail(cve configCVE) string {
+	details := make([]string, 0, 3)
+	if cve.Justification != "" {
+		details = append(details, cve.Justification)
+	}
+	if cve.Owner != "" {
+		details = append(details, "owner: "+cve.Owner)
+	}
+	if cve.Expires != "" {
+		details = append(details, "expires: "+cve.Expires)
+	}
+	return strings.Join(details, ", ")
+}
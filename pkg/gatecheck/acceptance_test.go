@@ -0,0 +1,224 @@
+package gatecheck
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+func Test_activeCVEAcceptances(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2025, time.June, 1, 15, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	acceptance := configCVERiskAcceptance{
+		Enabled:               true,
+		ExpirationWarningDays: 14,
+		CVEs: []configCVE{
+			{ID: "cve-no-expiration"},
+			{ID: "cve-expired", Expires: "2025-05-31", Owner: "team-a"},
+			{ID: "cve-expires-today", Expires: "2025-06-01"},
+			{ID: "cve-expires-soon", Expires: "2025-06-10", Owner: "team-b"},
+			{ID: "cve-expires-later", Expires: "2025-09-01"},
+			{ID: "cve-invalid", Expires: "June 2025"},
+		},
+	}
+
+	active, warnings := activeCVEAcceptances(acceptance, "grype")
+
+	wantActive := []string{"cve-no-expiration", "cve-expires-today", "cve-expires-soon", "cve-expires-later"}
+	if len(active) != len(wantActive) {
+		t.Fatalf("want: %v got: %+v", wantActive, active)
+	}
+	for i, cve := range active {
+		if cve.ID != wantActive[i] {
+			t.Fatalf("want: %s got: %s", wantActive[i], cve.ID)
+		}
+	}
+
+	wantWarnings := []string{"cve-expired", "cve-expires-today", "cve-expires-soon", "cve-invalid"}
+	if len(warnings) != len(wantWarnings) {
+		t.Fatalf("want: %d warnings got: %v", len(wantWarnings), warnings)
+	}
+	for i, warning := range warnings {
+		if !strings.HasPrefix(warning, wantWarnings[i]) {
+			t.Fatalf("want: %s warning got: %s", wantWarnings[i], warning)
+		}
+	}
+}
+
+func Test_ruleCVEAllow_scope(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	newReport := func() *artifacts.GrypeReportMin {
+		report := &artifacts.GrypeReportMin{}
+		for _, pkg := range [][2]string{{"openssl", "3.0.1"}, {"openssl", "3.0.2"}, {"curl", "8.0.0"}} {
+			match := artifacts.GrypeMatch{}
+			match.Vulnerability.ID = "cve-1"
+			match.Vulnerability.Severity = "High"
+			match.Artifact.Name = pkg[0]
+			match.Artifact.Version = pkg[1]
+			report.Matches = append(report.Matches, match)
+		}
+		return report
+	}
+
+	testTable := []struct {
+		label     string
+		cve       configCVE
+		wantCount int
+	}{
+		{label: "unscoped", cve: configCVE{ID: "cve-1"}, wantCount: 3},
+		{label: "package", cve: configCVE{ID: "CVE-1", Package: "openssl"}, wantCount: 2},
+		{label: "package-version", cve: configCVE{ID: "cve-1", Package: "openssl", Version: "3.0.2"}, wantCount: 1},
+		{label: "expired", cve: configCVE{ID: "cve-1", Expires: "2025-01-01"}, wantCount: 0},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := new(Config)
+			config.Grype.CVERiskAcceptance = configCVERiskAcceptance{Enabled: true, CVEs: []configCVE{testCase.cve}}
+
+			report := grypeVulnerabilities(newReport())
+			result := ruleCVEAllow(config.Grype, report)
+			if len(result.Accepted) != testCase.wantCount {
+				t.Fatalf("want: %d accepted got: %+v", testCase.wantCount, result.Accepted)
+			}
+			if len(report.Vulnerabilities) != 3-testCase.wantCount {
+				t.Fatalf("want: %d remaining got: %d", 3-testCase.wantCount, len(report.Vulnerabilities))
+			}
+		})
+	}
+
+	t.Run("detail", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.CVERiskAcceptance = configCVERiskAcceptance{Enabled: true, CVEs: []configCVE{
+			{ID: "cve-1", Owner: "platform", Justification: "not reachable", Expires: "2025-12-31"},
+		}}
+		result := ruleCVEAllow(config.Grype, grypeVulnerabilities(newReport()))
+		want := "not reachable, owner: platform, expires: 2025-12-31"
+		if result.Accepted[0].Detail != want {
+			t.Fatalf("want: %q got: %q", want, result.Accepted[0].Detail)
+		}
+	})
+}
+
+func Test_matchesCVE(t *testing.T) {
+	scope := packageScope{
+		Name:    "lodash",
+		Version: "4.17.20",
+		PURL:    "pkg:npm/lodash@4.17.20?repository_url=https://registry.npmjs.org",
+		Type:    "npm",
+	}
+
+	testTable := []struct {
+		label string
+		cve   configCVE
+		want  bool
+	}{
+		{label: "id-only", cve: configCVE{ID: "CVE-2021-23337"}, want: true},
+		{label: "other-id", cve: configCVE{ID: "CVE-2020-8203"}, want: false},
+		{label: "package", cve: configCVE{ID: "CVE-2021-23337", Package: "Lodash"}, want: true},
+		{label: "other-package", cve: configCVE{ID: "CVE-2021-23337", Package: "underscore"}, want: false},
+		{label: "in-range", cve: configCVE{ID: "CVE-2021-23337", VersionRange: ">=4.0.0, <4.17.21"}, want: true},
+		{label: "out-of-range", cve: configCVE{ID: "CVE-2021-23337", VersionRange: ">=4.17.21"}, want: false},
+		{label: "purl-no-version", cve: configCVE{ID: "CVE-2021-23337", PURL: "pkg:npm/lodash"}, want: true},
+		{label: "purl-version", cve: configCVE{ID: "CVE-2021-23337", PURL: "pkg:npm/lodash@4.17.20"}, want: true},
+		{label: "purl-other-version", cve: configCVE{ID: "CVE-2021-23337", PURL: "pkg:npm/lodash@4.17.21"}, want: false},
+		{label: "ecosystem", cve: configCVE{ID: "CVE-2021-23337", Ecosystem: "npm"}, want: true},
+		{label: "other-ecosystem", cve: configCVE{ID: "CVE-2021-23337", Ecosystem: "go-module"}, want: false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			if got := matchesCVE(testCase.cve, "CVE-2021-23337", scope); got != testCase.want {
+				t.Fatalf("want: %t got: %t", testCase.want, got)
+			}
+		})
+	}
+
+	t.Run("purl-ecosystem", func(t *testing.T) {
+		// cyclonedx components don't have a package type, the purl type is used
+		cve := configCVE{ID: "CVE-2021-23337", Ecosystem: "deb"}
+		if !matchesCVE(cve, "CVE-2021-23337", packageScope{PURL: "pkg:deb/debian/libssl1.1@1.1.1n-0+deb11u4"}) {
+			t.Fatal("want: match on purl type got: no match")
+		}
+	})
+}
+
+func Test_versionInRange(t *testing.T) {
+	testTable := []struct {
+		version      string
+		versionRange string
+		ecosystem    string
+		want         bool
+	}{
+		{version: "1.2.3", versionRange: "1.2.3", want: true},
+		{version: "v1.2.3", versionRange: "=1.2.3", want: true},
+		{version: "1.10.0", versionRange: ">1.9.0", want: true},
+		{version: "1.2.3", versionRange: ">=1.0.0, <1.2.3", want: false},
+		{version: "1.2.3", versionRange: "!=1.2.3", want: false},
+		{version: "1.1.1n-0+deb11u4", versionRange: "<1.1.1t", ecosystem: "deb", want: true},
+		{version: "1.1.1n-0+deb11u4", versionRange: ">=1.1.1n-0+deb11u3, <=1.1.1n-0+deb11u5", ecosystem: "deb", want: true},
+		{version: "2.0.0-beta", versionRange: "<2.0.0.1", want: true},
+		{version: "1.0", versionRange: ">=1.0.0", want: true},
+		{version: "1.0.0-rc1", versionRange: "<1.0.0", want: true},
+	}
+
+	for _, testCase := range testTable {
+		if got := versionInRange(testCase.version, testCase.versionRange, testCase.ecosystem); got != testCase.want {
+			t.Fatalf("version %s range %q want: %t got: %t", testCase.version, testCase.versionRange, testCase.want, got)
+		}
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	testTable := []struct {
+		a         string
+		b         string
+		ecosystem string
+		want      int
+	}{
+		{a: "1.0", b: "1.0.0", want: 0},
+		{a: "1.0.0.0", b: "v1", want: 0},
+		{a: "1.0", b: "1.0.1", want: -1},
+		{a: "1.0.0-rc1", b: "1.0.0", want: -1},
+		{a: "1.0.0", b: "1.0.0-rc1", want: 1},
+		{a: "1.0.0-rc1", b: "1.0.0-rc2", want: -1},
+		{a: "1.0.0-rc1", b: "0.9.9", want: 1},
+		{a: "1.0.0-1", b: "1.0.0", ecosystem: "deb", want: 1},
+		{a: "1.0.0-1.el8", b: "1.0.0", ecosystem: "RPM", want: 1},
+		{a: "1.0.0-1", b: "1.0.0", ecosystem: "npm", want: -1},
+	}
+
+	for _, testCase := range testTable {
+		if got := compareVersions(testCase.a, testCase.b, testCase.ecosystem); got != testCase.want {
+			t.Fatalf("%s vs %s (%s) want: %d got: %d", testCase.a, testCase.b, testCase.ecosystem, testCase.want, got)
+		}
+	}
+}
+
+func Test_ruleCVEDeny_scope(t *testing.T) {
+	report := &artifacts.GrypeReportMin{}
+	if err := json.NewDecoder(MustOpen("../../test/grype-report.json", t)).Decode(report); err != nil {
+		t.Fatal(err)
+	}
+	match := report.Matches[0]
+
+	config := new(Config)
+	config.Grype.CVELimit = configCVELimit{Enabled: true, CVEs: []configCVE{
+		{ID: match.Vulnerability.ID, Ecosystem: "not-" + match.Artifact.Type},
+	}}
+	if !ruleCVEDeny(config.Grype, grypeVulnerabilities(report)).Passed() {
+		t.Fatal("want: pass for a CVE denied in another ecosystem got: fail")
+	}
+
+	config.Grype.CVELimit.CVEs[0].Ecosystem = match.Artifact.Type
+	config.Grype.CVELimit.CVEs[0].PURL = match.Artifact.PURL
+	if ruleCVEDeny(config.Grype, grypeVulnerabilities(report)).Passed() {
+		t.Fatal("want: fail for a CVE denied in the package got: pass")
+	}
+}
@@ -0,0 +1,203 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+// baseline the findings from a previous report or bundle
+//
+// Findings in the baseline are accepted before the limits are evaluated,
+// so the limits only apply to new findings
+type baseline struct {
+	// vulnerabilities keyed by CVE and package, shared by grype, cyclonedx and trivy
+	vulnerabilities map[string]bool
+	// semgrep results keyed by check_id and path
+	semgrepResults map[string]bool
+	// gitleaks secrets keyed by fingerprint
+	secrets map[string]bool
+}
+
+func newBaseline() *baseline {
+	return &baseline{
+		vulnerabilities: make(map[string]bool),
+		semgrepResults:  make(map[string]bool),
+		secrets:         make(map[string]bool),
+	}
+}
+
+func vulnerabilityKey(id string, pkg string) string {
+	return id + "|" + pkg
+}
+
+func semgrepKey(result artifacts.SemgrepResults) string {
+	return result.CheckID + "|" + result.Path
+}
+
+// gitleaksKey the fingerprint, secrets without one have an empty key and can't be matched
+func gitleaksKey(finding artifacts.GitleaksFinding) string {
+	return finding.Fingerprint
+}
+
+// loadBaseline decodes a previous report or every supported report in a previous bundle
+func loadBaseline(src io.Reader, filename string) (*baseline, error) {
+	b := newBaseline()
+
+	artifactType, src, err := detectArtifactType(filename, src)
+	if err != nil {
+		slog.Error("unsupported baseline file type", "filename", filename, "error", err)
+		return nil, errors.New("cannot load baseline: unsupported file type, See log for details")
+	}
+
+	if artifactType != artifacts.TypeBundle {
+		return b, b.add(artifactType, src)
+	}
+
+	bundle := archive.NewBundle()
+	if err := archive.UntarGzipBundle(src, bundle); err != nil {
+		slog.Error("decode baseline bundle", "filename", filename, "error", err)
+		return nil, errors.New("cannot load baseline: Bundle decoding failed, See log for details")
+	}
+
+	for _, fileLabel := range bundle.Labels() {
+		artifactType, src, err := detectArtifactType(fileLabel, bytes.NewReader(bundle.FileBytes(fileLabel)))
+		if err != nil {
+			slog.Debug("unsupported file type in baseline bundle, skip", "file_label", fileLabel)
+			continue
+		}
+		if err := b.add(artifactType, src); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// add the findings from a single report, types without baseline support are ignored
+func (b *baseline) add(artifactType string, src io.Reader) error {
+	switch artifactType {
+	case artifacts.TypeGrype:
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return b.decodeErr(artifactType, err)
+		}
+		for _, match := range report.Matches {
+			b.vulnerabilities[vulnerabilityKey(match.Vulnerability.ID, match.Artifact.Name)] = true
+		}
+
+	case artifacts.TypeCyclonedx:
+		report := &artifacts.CyclonedxReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return b.decodeErr(artifactType, err)
+		}
+		for idx, vulnerability := range report.Vulnerabilities {
+			for _, component := range report.AffectedComponents(idx) {
+				b.vulnerabilities[vulnerabilityKey(vulnerability.ID, component.Name)] = true
+			}
+		}
+
+	case artifacts.TypeTrivy:
+		report := &artifacts.TrivyReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return b.decodeErr(artifactType, err)
+		}
+		for _, vulnerability := range report.Vulnerabilities() {
+			b.vulnerabilities[vulnerabilityKey(vulnerability.VulnerabilityID, vulnerability.PkgName)] = true
+		}
+
+	case artifacts.TypeSemgrep:
+		report := &artifacts.SemgrepReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return b.decodeErr(artifactType, err)
+		}
+		for _, result := range report.Results {
+			b.semgrepResults[semgrepKey(result)] = true
+		}
+
+	case artifacts.TypeGitleaks:
+		report := artifacts.GitLeaksReportMin{}
+		if err := json.NewDecoder(src).Decode(&report); err != nil {
+			return b.decodeErr(artifactType, err)
+		}
+		for _, finding := range report {
+			if key := gitleaksKey(finding); key != "" {
+				b.secrets[key] = true
+			}
+		}
+
+	default:
+		slog.Debug("baseline not supported for artifact type, skip", "filetype", artifactType)
+	}
+	return nil
+}
+
+func (b *baseline) decodeErr(artifactType string, err error) error {
+	slog.Error("decode baseline report", "filetype", artifactType, "error", err)
+	return errors.New("cannot load baseline: Report decoding failed, See log for details")
+}
+
+// Baseline Rules
+//
+// Findings in the baseline are removed from the report and recorded as accepted
+
+// ruleVulnerabilityBaseline a vulnerability is in the baseline if every affected package is in the baseline
+func ruleVulnerabilityBaseline(b *baseline, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleBaseline, report.Artifact)
+	if b == nil {
+		return result.skip()
+	}
+
+	report.Vulnerabilities = slices.DeleteFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
+		inBaseline := !slices.ContainsFunc(vulnerability.Scopes, func(scope packageScope) bool {
+			return scope.Name == "" || !b.vulnerabilities[vulnerabilityKey(vulnerability.ID, scope.Name)]
+		})
+		if !inBaseline {
+			return false
+		}
+		slog.Info("baseline finding", "artifact", report.Artifact, "id", vulnerability.ID, "package", vulnerability.Finding.Package)
+		result = result.accept(vulnerability.Finding)
+		return true
+	})
+	return result
+}
+
+func ruleSemgrepBaseline(b *baseline, report *artifacts.SemgrepReportMin) RuleResult {
+	result := newRuleResult(RuleBaseline, "semgrep")
+	if b == nil {
+		return result.skip()
+	}
+
+	report.Results = slices.DeleteFunc(report.Results, func(semgrepResult artifacts.SemgrepResults) bool {
+		if !b.semgrepResults[semgrepKey(semgrepResult)] {
+			return false
+		}
+		slog.Info("baseline finding", "artifact", "semgrep", "check_id", semgrepResult.CheckID, "path", semgrepResult.Path)
+		result = result.accept(semgrepFinding(semgrepResult))
+		return true
+	})
+	return result
+}
+
+func ruleGitleaksBaseline(b *baseline, report *artifacts.GitLeaksReportMin) RuleResult {
+	result := newRuleResult(RuleBaseline, "gitleaks")
+	if b == nil {
+		return result.skip()
+	}
+
+	*report = slices.DeleteFunc(*report, func(finding artifacts.GitleaksFinding) bool {
+		if key := gitleaksKey(finding); key == "" || !b.secrets[key] {
+			return false
+		}
+		slog.Info("baseline finding", "artifact", "gitleaks", "rule_id", finding.RuleID, "file", finding.File)
+		result = result.accept(gitleaksFinding(finding))
+		return true
+	})
+	return result
+}
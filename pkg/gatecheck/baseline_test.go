@@ -0,0 +1,138 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+func Test_baselineGrype(t *testing.T) {
+	decodeReport := func(t *testing.T) *artifacts.GrypeReportMin {
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/grype-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+	config := new(Config)
+	config.Grype.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 0}
+
+	t.Run("no-new-findings", func(t *testing.T) {
+		base, err := loadBaseline(MustOpen("../../test/grype-report.json", t), "previous.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := validateGrypeRules(config, decodeReport(t), nil, nil, base, false)
+		if err := result.Err(); err != nil {
+			t.Fatalf("want: pass got: %v", err)
+		}
+	})
+
+	t.Run("new-finding", func(t *testing.T) {
+		previous := decodeReport(t)
+		newMatch := previous.SelectBySeverity("critical")[0]
+		previous.Matches = slices.DeleteFunc(previous.Matches, func(match artifacts.GrypeMatch) bool {
+			return match.Vulnerability.ID == newMatch.Vulnerability.ID && match.Artifact.Name == newMatch.Artifact.Name
+		})
+		buf := new(bytes.Buffer)
+		_ = json.NewEncoder(buf).Encode(previous)
+
+		base, err := loadBaseline(buf, "previous-grype.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := validateGrypeRules(config, decodeReport(t), nil, nil, base, false)
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 1 || failures[0].Rule != RuleSeverityLimit {
+			t.Fatalf("want: 1 severity limit failure got: %+v", failures)
+		}
+		for _, finding := range failures[0].Findings {
+			if finding.ID != newMatch.Vulnerability.ID || finding.Package != newMatch.Artifact.Name {
+				t.Fatalf("want: only %s %s got: %+v", newMatch.Vulnerability.ID, newMatch.Artifact.Name, finding)
+			}
+		}
+	})
+
+	t.Run("no-baseline", func(t *testing.T) {
+		result := validateGrypeRules(config, decodeReport(t), nil, nil, nil, false)
+		if result.Rules[1].Rule != RuleBaseline || result.Rules[1].Status != RuleSkipped {
+			t.Fatalf("want: skipped baseline rule got: %+v", result.Rules[1])
+		}
+		if result.Passed() {
+			t.Fatal("want: severity limit failure got: pass")
+		}
+	})
+}
+
+func Test_baselineSemgrepAndGitleaks(t *testing.T) {
+	t.Run("semgrep", func(t *testing.T) {
+		config := new(Config)
+		config.Semgrep.SeverityLimit.Error = configLimit{Enabled: true, Limit: 0}
+		config.Semgrep.SeverityLimit.Warning = configLimit{Enabled: true, Limit: 0}
+
+		base, err := loadBaseline(MustOpen("../../test/semgrep-sast-report.json", t), "semgrep-sast-report.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		report := &artifacts.SemgrepReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/semgrep-sast-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		if err := validateSemgrepRules(config, report, base, false).Err(); err != nil {
+			t.Fatalf("want: pass got: %v", err)
+		}
+	})
+
+	t.Run("gitleaks", func(t *testing.T) {
+		config := new(Config)
+		config.Gitleaks.LimitEnabled = true
+
+		previous := artifacts.GitLeaksReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/gitleaks-report.json", t)).Decode(&previous); err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		_ = json.NewEncoder(buf).Encode(previous[1:])
+		base, err := loadBaseline(buf, "gitleaks-report.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report := artifacts.GitLeaksReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/gitleaks-report.json", t)).Decode(&report); err != nil {
+			t.Fatal(err)
+		}
+		result := validateGitleaksRules(config, &report, base, false)
+		limitResult := result.Rules[len(result.Rules)-1]
+		if limitResult.Passed() || len(limitResult.Findings) != 1 {
+			t.Fatalf("want: 1 new secret got: %+v", limitResult.Findings)
+		}
+		if limitResult.Findings[0].ID != previous[0].RuleID {
+			t.Fatalf("want: %s got: %s", previous[0].RuleID, limitResult.Findings[0].ID)
+		}
+	})
+}
+
+func Test_loadBaselineBundle(t *testing.T) {
+	bundle := archive.NewBundle()
+	_ = bundle.AddFrom(MustOpen("../../test/grype-report.json", t), "grype-report.json", nil)
+	_ = bundle.AddFrom(MustOpen("../../test/semgrep-sast-report.json", t), "semgrep-sast-report.json", nil)
+	_ = bundle.AddFrom(MustOpen("../../test/gitleaks-report.json", t), "gitleaks-report.json", nil)
+	buf := new(bytes.Buffer)
+	if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := loadBaseline(buf, "previous.gatecheck-bundle.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(base.vulnerabilities) == 0 || len(base.semgrepResults) == 0 || len(base.secrets) == 0 {
+		t.Fatalf("want: findings from every report got: %d vulnerabilities %d semgrep %d secrets",
+			len(base.vulnerabilities), len(base.semgrepResults), len(base.secrets))
+	}
+}
@@ -1,8 +1,16 @@
 package gatecheck
 
 import (
+	"crypto"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gatecheckdev/gatecheck/pkg/archive"
 )
@@ -85,3 +93,282 @@ func RemoveFromBundle(bundleRWS io.ReadWriteSeeker, label string) error {
 	slog.Info("bundle write after remove success", "bytes_written", n, "label", label)
 	return nil
 }
+
+// SignBundle signs the manifest of an existing bundle with a PEM encoded ed25519 or ECDSA private key
+//
+// An existing signature is replaced
+func SignBundle(bundleRWS io.ReadWriteSeeker, keySrc io.Reader) error {
+	key, err := readPrivateKey(keySrc)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("load bundle")
+	bundle := archive.NewBundle()
+	if err := archive.UntarGzipBundle(bundleRWS, bundle); err != nil {
+		return err
+	}
+
+	if err := bundle.Sign(key); err != nil {
+		return err
+	}
+
+	// Seek errors are unlikely so just capture for edge cases
+	_, seekErr := bundleRWS.Seek(0, io.SeekStart)
+
+	slog.Debug("write bundle", "seek_err", seekErr)
+	n, err := archive.TarGzipBundle(bundleRWS, bundle)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("bundle sign success", "bytes_written", n, "key_id", bundle.Signature().KeyID, "algorithm", bundle.Signature().Algorithm)
+	return nil
+}
+
+// VerifyBundle checks the bundle content against the manifest digests and writes a report of the drift to dst
+//
+// The signature is verified with a PEM encoded public key if keySrc isn't nil.
+// The error wraps archive.ErrIntegrity, archive.ErrUnsigned or archive.ErrSignatureInvalid
+func VerifyBundle(dst io.Writer, bundleSrc io.Reader, keySrc io.Reader) error {
+	var publicKey crypto.PublicKey
+	if keySrc != nil {
+		var err error
+		if publicKey, err = readPublicKey(keySrc); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("load bundle")
+	bundle := archive.NewBundle()
+	integrityErr := archive.UntarGzipBundle(bundleSrc, bundle)
+	if integrityErr != nil && !errors.Is(integrityErr, archive.ErrIntegrity) {
+		return integrityErr
+	}
+
+	drift := bundle.Drift()
+	if drift.Empty() {
+		_, _ = fmt.Fprintf(dst, "Integrity: OK (%d files)\n", len(bundle.Manifest().Files))
+	} else {
+		_, _ = fmt.Fprintln(dst, "Integrity: FAILED")
+		for _, label := range drift.Changed {
+			_, _ = fmt.Fprintf(dst, "  changed:         %s\n", label)
+		}
+		for _, label := range drift.Missing {
+			_, _ = fmt.Fprintf(dst, "  missing:         %s\n", label)
+		}
+		for _, label := range drift.Unlisted {
+			_, _ = fmt.Fprintf(dst, "  not in manifest: %s\n", label)
+		}
+	}
+
+	signature := bundle.Signature()
+	var signatureErr error
+	switch {
+	case publicKey == nil && signature == nil:
+		_, _ = fmt.Fprintln(dst, "Signature: not signed")
+	case publicKey == nil:
+		_, _ = fmt.Fprintf(dst, "Signature: not verified, signed by %s key %s, use a public key to verify\n", signature.Algorithm, signature.KeyID)
+	default:
+		signatureErr = bundle.Verify(publicKey)
+		if signatureErr != nil {
+			_, _ = fmt.Fprintf(dst, "Signature: FAILED (%v)\n", signatureErr)
+		} else {
+			_, _ = fmt.Fprintf(dst, "Signature: OK (%s key %s signed at %s)\n",
+				signature.Algorithm, signature.KeyID, signature.SignedAt.Format(time.RFC3339))
+		}
+	}
+
+	return errors.Join(integrityErr, signatureErr)
+}
+
+// ExtractBundle writes the files in the bundle that match the label patterns and tags to dir
+//
+// Every file is extracted if there are no patterns or tags, existing files are overwritten
+func ExtractBundle(bundleSrc io.Reader, dir string, patterns []string, tags []string) error {
+	bundle, labels, err := selectFromBundle(bundleSrc, patterns, tags)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		// labels are file names in a flat directory, never write outside of dir
+		if label != filepath.Base(label) || label == ".." {
+			slog.Error("invalid file label in bundle, skip", "label", label)
+			continue
+		}
+		filename := filepath.Join(dir, label)
+		if err := os.WriteFile(filename, bundle.FileBytes(label), 0o644); err != nil {
+			return err
+		}
+		slog.Info("bundle extract", "label", label, "filename", filename)
+	}
+	return nil
+}
+
+// CatBundle writes the content of the single file in the bundle that matches the label pattern and tags to dst
+func CatBundle(dst io.Writer, bundleSrc io.Reader, pattern string, tags []string) error {
+	patterns := []string{}
+	if pattern != "" {
+		patterns = append(patterns, pattern)
+	}
+	bundle, labels, err := selectFromBundle(bundleSrc, patterns, tags)
+	if err != nil {
+		return err
+	}
+	if len(labels) > 1 {
+		return fmt.Errorf("%d files in the bundle match, cat needs exactly one: %s", len(labels), strings.Join(labels, ", "))
+	}
+
+	_, err = bundle.WriteFileTo(dst, labels[0])
+	return err
+}
+
+// Label collision policies for MergeBundles
+const (
+	// MergeCollisionError fail if more than one bundle has the same label
+	MergeCollisionError = "error"
+	// MergeCollisionPrefix prefix colliding labels with the name of their source bundle
+	MergeCollisionPrefix = "prefix"
+	// MergeCollisionNewest keep the file that was added last
+	MergeCollisionNewest = "newest"
+)
+
+// BundleSource a bundle to merge, the name is used to prefix colliding labels
+type BundleSource struct {
+	Name   string
+	Reader io.Reader
+}
+
+// MergeBundles combines the files of every source bundle into a new bundle
+//
+// The manifest tags, digests and added times are kept.
+// Labels in more than one source are handled by the collision policy,
+// with MergeCollisionNewest the later source wins if the added times are the same
+func MergeBundles(dst io.Writer, policy string, sources ...BundleSource) error {
+	switch policy {
+	case MergeCollisionError, MergeCollisionPrefix, MergeCollisionNewest:
+	default:
+		return fmt.Errorf("unsupported collision policy '%s', must be error, prefix, or newest", policy)
+	}
+
+	bundles := make([]*archive.Bundle, 0, len(sources))
+	sourceNames := make(map[string][]string)
+	for _, source := range sources {
+		slog.Debug("load bundle", "name", source.Name)
+		bundle := archive.NewBundle()
+		if err := archive.UntarGzipBundle(source.Reader, bundle); err != nil {
+			return fmt.Errorf("%s: %w", source.Name, err)
+		}
+		bundles = append(bundles, bundle)
+		for _, label := range bundle.Labels() {
+			sourceNames[label] = append(sourceNames[label], source.Name)
+		}
+	}
+
+	collisions := []string{}
+	for label, names := range sourceNames {
+		if len(names) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", label, strings.Join(names, ", ")))
+		}
+	}
+	sort.Strings(collisions)
+	if len(collisions) > 0 && policy == MergeCollisionError {
+		return fmt.Errorf("label collision in merged bundles: %s", strings.Join(collisions, "; "))
+	}
+
+	merged := archive.NewBundle()
+	mergedLabels := make(map[string]bool)
+	for idx, bundle := range bundles {
+		for _, label := range bundle.Labels() {
+			mergedLabel := label
+			collision := len(sourceNames[label]) > 1
+			replace := false
+
+			switch {
+			case collision && policy == MergeCollisionPrefix:
+				mergedLabel = bundleSourcePrefix(sources[idx].Name) + "-" + label
+			case collision && policy == MergeCollisionNewest && mergedLabels[label]:
+				if merged.AddedAt(label).After(bundle.AddedAt(label)) {
+					slog.Info("merge label collision, keep newest", "label", label, "skip_source", sources[idx].Name)
+					continue
+				}
+				slog.Info("merge label collision, replace with newest", "label", label, "source", sources[idx].Name)
+				replace = true
+			}
+
+			// a prefixed label can match a label in another bundle or two sources can have the same name
+			if mergedLabels[mergedLabel] && !replace {
+				return fmt.Errorf("label collision in merged bundles: %s, rename the source bundles", mergedLabel)
+			}
+
+			if err := merged.CopyFile(bundle, label, mergedLabel); err != nil {
+				return err
+			}
+			mergedLabels[mergedLabel] = true
+			slog.Debug("merge bundle file", "source", sources[idx].Name, "label", label, "merged_label", mergedLabel)
+		}
+	}
+
+	n, err := archive.TarGzipBundle(dst, merged)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("bundle merge success", "bytes_written", n, "bundles", len(sources), "files", len(merged.Labels()), "collisions", len(collisions))
+	return nil
+}
+
+// bundleSourcePrefix the bundle file name without the directory and archive extensions
+func bundleSourcePrefix(name string) string {
+	name = filepath.Base(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".gz"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// selectFromBundle load the bundle and select labels, an error if nothing matches
+func selectFromBundle(bundleSrc io.Reader, patterns []string, tags []string) (*archive.Bundle, []string, error) {
+	slog.Debug("load bundle")
+	bundle := archive.NewBundle()
+	if err := archive.UntarGzipBundle(bundleSrc, bundle); err != nil {
+		return nil, nil, err
+	}
+
+	labels, err := bundle.Select(patterns, tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	slog.Debug("bundle select", "patterns", patterns, "tags", tags, "labels", labels)
+	if len(labels) == 0 {
+		return nil, nil, fmt.Errorf("no files in the bundle match labels %v and tags %v", patterns, tags)
+	}
+	return bundle, labels, nil
+}
+
+func readPrivateKey(keySrc io.Reader) (crypto.Signer, error) {
+	if keySrc == nil {
+		return nil, errors.New("a private key is required to sign a bundle")
+	}
+	keyBytes, err := io.ReadAll(keySrc)
+	if err != nil {
+		return nil, err
+	}
+	return archive.ParsePrivateKey(keyBytes)
+}
+
+func readPublicKey(keySrc io.Reader) (crypto.PublicKey, error) {
+	if keySrc == nil {
+		return nil, errors.New("a public key is required to verify a bundle signature")
+	}
+	keyBytes, err := io.ReadAll(keySrc)
+	if err != nil {
+		return nil, err
+	}
+	return archive.ParsePublicKey(keyBytes)
+}
@@ -0,0 +1,149 @@
+package gatecheck
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+)
+
+func testBundle(t *testing.T) []byte {
+	bundle := archive.NewBundle()
+	bundle.Add([]byte("grype"), "grype-report.json", []string{"image=api"})
+	bundle.Add([]byte("trivy"), "trivy-report.json", []string{"image=web"})
+	bundle.Add([]byte("gitleaks"), "gitleaks-report.json", []string{"image=api"})
+	buf := new(bytes.Buffer)
+	if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractBundle(t *testing.T) {
+	content := testBundle(t)
+	dir := filepath.Join(t.TempDir(), "out")
+
+	if err := ExtractBundle(bytes.NewReader(content), dir, nil, []string{"image=api"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("want: 2 extracted files got: %v", entries)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "grype-report.json"))
+	if string(got) != "grype" {
+		t.Fatalf("want: grype got: %s", got)
+	}
+
+	if err := ExtractBundle(bytes.NewReader(content), dir, []string{"semgrep*"}, nil); err == nil {
+		t.Fatal("want: no match error got: nil")
+	}
+}
+
+func TestCatBundle(t *testing.T) {
+	content := testBundle(t)
+
+	testTable := []struct {
+		label   string
+		pattern string
+		tags    []string
+		want    string
+		wantErr bool
+	}{
+		{label: "label", pattern: "trivy-report.json", want: "trivy"},
+		{label: "glob", pattern: "gitleaks*", want: "gitleaks"},
+		{label: "tag", tags: []string{"image=web"}, want: "trivy"},
+		{label: "multiple", pattern: "*.json", wantErr: true},
+		{label: "no-match", pattern: "semgrep*", wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			dst := new(bytes.Buffer)
+			err := CatBundle(dst, bytes.NewReader(content), testCase.pattern, testCase.tags)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("want error: %v got: %v", testCase.wantErr, err)
+			}
+			if dst.String() != testCase.want {
+				t.Fatalf("want: %s got: %s", testCase.want, dst.String())
+			}
+		})
+	}
+}
+
+func TestMergeBundles(t *testing.T) {
+	newSource := func(name string, files map[string]string, tag string) BundleSource {
+		bundle := archive.NewBundle()
+		for label, content := range files {
+			bundle.Add([]byte(content), label, []string{tag})
+		}
+		buf := new(bytes.Buffer)
+		if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+			t.Fatal(err)
+		}
+		return BundleSource{Name: name, Reader: buf}
+	}
+	sources := func() []BundleSource {
+		api := newSource("build/api.gatecheck-bundle.tar.gz", map[string]string{"grype-report.json": "api", "gitleaks-report.json": "secrets"}, "service=api")
+		// added after the api bundle
+		web := newSource("build/web.gatecheck-bundle.tar.gz", map[string]string{"grype-report.json": "web"}, "service=web")
+		return []BundleSource{api, web}
+	}
+
+	testTable := []struct {
+		label   string
+		policy  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{label: "error", policy: MergeCollisionError, wantErr: true},
+		{label: "unsupported", policy: "rename", wantErr: true},
+		{
+			label:  "prefix",
+			policy: MergeCollisionPrefix,
+			want: map[string]string{
+				"api.gatecheck-bundle-grype-report.json": "api",
+				"web.gatecheck-bundle-grype-report.json": "web",
+				"gitleaks-report.json":                   "secrets",
+			},
+		},
+		{
+			label:  "newest",
+			policy: MergeCollisionNewest,
+			want:   map[string]string{"grype-report.json": "web", "gitleaks-report.json": "secrets"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := MergeBundles(buf, testCase.policy, sources()...)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("want error: %v got: %v", testCase.wantErr, err)
+			}
+			if testCase.wantErr {
+				return
+			}
+
+			merged := archive.NewBundle()
+			if err := archive.UntarGzipBundle(buf, merged); err != nil {
+				t.Fatal(err)
+			}
+			if len(merged.Labels()) != len(testCase.want) {
+				t.Fatalf("want: %v got: %v", testCase.want, merged.Labels())
+			}
+			for label, content := range testCase.want {
+				if got := string(merged.FileBytes(label)); got != content {
+					t.Fatalf("want: %s %s got: %s", label, content, got)
+				}
+			}
+			// tags and digests are kept from the source bundle
+			files := merged.Manifest().Files
+			if tags := files["gitleaks-report.json"].Tags; len(tags) != 1 || tags[0] != "service=api" {
+				t.Fatalf("want: service=api tag got: %v", tags)
+			}
+		})
+	}
+}
@@ -26,9 +26,13 @@ type Config struct {
 	Metadata  configMetadata       `json:"metadata"  toml:"metadata"  yaml:"metadata"`
 	Grype     reportWithCVEs       `json:"grype"     toml:"grype"     yaml:"grype"`
 	Cyclonedx reportWithCVEs       `json:"cyclonedx" toml:"cyclonedx" yaml:"cyclonedx"`
+	Trivy     reportWithCVEs       `json:"trivy"     toml:"trivy"     yaml:"trivy"`
+	SBOM      configSBOMReport     `json:"sbom"      toml:"sbom"      yaml:"sbom"`
+	Licenses  configLicenses       `json:"licenses"  toml:"licenses"  yaml:"licenses"`
 	Semgrep   configSemgrepReport  `json:"semgrep"   toml:"semgrep"   yaml:"semgrep"`
 	Gitleaks  configGitleaksReport `json:"gitleaks"  toml:"gitleaks"  yaml:"gitleaks"`
 	Coverage  configCoverageReport `json:"coverage"  toml:"coverage"  yaml:"coverage"`
+	Sarif     configSarifReport    `json:"sarif"     toml:"sarif"     yaml:"sarif"`
 }
 
 func (c *Config) String() string {
@@ -77,6 +81,55 @@ type configSemgrepImpactRiskAcceptance struct {
 	Low     bool `json:"low"     toml:"low"     yaml:"low"`
 }
 
+// configSBOMReport policy for the components in Syft and Cyclonedx SBOMs
+type configSBOMReport struct {
+	PackageLimit   configSBOMPackageLimit `json:"packageLimit"   toml:"packageLimit"   yaml:"packageLimit"`
+	RequireVersion bool                   `json:"requireVersion" toml:"requireVersion" yaml:"requireVersion"`
+	RequirePURL    bool                   `json:"requirePurl"    toml:"requirePurl"    yaml:"requirePurl"`
+	ComponentLimit configLimit            `json:"componentLimit" toml:"componentLimit" yaml:"componentLimit"`
+}
+
+type configSBOMPackageLimit struct {
+	Enabled  bool                `json:"enabled"  toml:"enabled"  yaml:"enabled"`
+	Packages []configSBOMPackage `json:"packages" toml:"packages" yaml:"packages"`
+}
+
+// configSBOMPackage selects a package by name, an empty version matches every version
+type configSBOMPackage struct {
+	Name    string `json:"name"    toml:"name"    yaml:"name"`
+	Version string `json:"version" toml:"version" yaml:"version"`
+}
+
+// configLicenses license compliance for SBOM components
+//
+// Allowed and Denied are SPDX IDs or expressions, when Allowed is empty
+// any license that isn't denied is permitted
+type configLicenses struct {
+	Enabled    bool                `json:"enabled"    toml:"enabled"    yaml:"enabled"`
+	Allowed    []string            `json:"allowed"    toml:"allowed"    yaml:"allowed"`
+	Denied     []string            `json:"denied"     toml:"denied"     yaml:"denied"`
+	Exceptions []configSBOMPackage `json:"exceptions" toml:"exceptions" yaml:"exceptions"`
+}
+
+type configSarifReport struct {
+	// Tools limits validation to runs from these tools, all runs are validated if empty
+	Tools              []string              `json:"tools"              toml:"tools"              yaml:"tools"`
+	LevelLimit         configSarifLevelLimit `json:"levelLimit"         toml:"levelLimit"         yaml:"levelLimit"`
+	RuleLimit          configSarifRuleIDs    `json:"ruleLimit"          toml:"ruleLimit"          yaml:"ruleLimit"`
+	RuleRiskAcceptance configSarifRuleIDs    `json:"ruleRiskAcceptance" toml:"ruleRiskAcceptance" yaml:"ruleRiskAcceptance"`
+}
+
+type configSarifLevelLimit struct {
+	Error   configLimit `json:"error"   toml:"error"   yaml:"error"`
+	Warning configLimit `json:"warning" toml:"warning" yaml:"warning"`
+	Note    configLimit `json:"note"    toml:"note"    yaml:"note"`
+}
+
+type configSarifRuleIDs struct {
+	Enabled bool     `json:"enabled" toml:"enabled" yaml:"enabled"`
+	RuleIDs []string `json:"ruleIDs" toml:"ruleIDs" yaml:"ruleIDs"`
+}
+
 type configMetadata struct {
 	Tags []string `json:"tags" toml:"tags" yaml:"tags"`
 }
@@ -84,19 +137,37 @@ type configMetadata struct {
 type reportWithCVEs struct {
 	SeverityLimit      configServerityLimit     `json:"severityLimit"      toml:"severityLimit"      yaml:"severityLimit"`
 	EPSSLimit          configEPSSLimit          `json:"epssLimit"          toml:"epssLimit"          yaml:"epssLimit"`
+	CVSSLimit          configCVSSLimit          `json:"cvssLimit"          toml:"cvssLimit"          yaml:"cvssLimit"`
+	RiskScoreLimit     configRiskScoreLimit     `json:"riskScoreLimit"     toml:"riskScoreLimit"     yaml:"riskScoreLimit"`
 	KEVLimitEnabled    bool                     `json:"kevLimitEnabled"    toml:"kevLimitEnabled"    yaml:"kevLimitEnabled"`
+	KEVLimit           configKEVLimit           `json:"kevLimit"           toml:"kevLimit"           yaml:"kevLimit"`
 	CVELimit           configCVELimit           `json:"cveLimit"           toml:"cveLimit"           yaml:"cveLimit"`
 	EPSSRiskAcceptance configEPSSRiskAcceptance `json:"epssRiskAcceptance" toml:"epssRiskAcceptance" yaml:"epssRiskAcceptance"`
 	CVERiskAcceptance  configCVERiskAcceptance  `json:"cveRiskAcceptance"  toml:"cveRiskAcceptance"  yaml:"cveRiskAcceptance"`
+	FixAvailability    configFixAvailability    `json:"fixAvailability"    toml:"fixAvailability"    yaml:"fixAvailability"`
 }
 
+// configFixAvailability separates vulnerabilities without a fix from the EPSS and severity limits
+type configFixAvailability struct {
+	// FixableOnly the EPSS and severity limits only count vulnerabilities with a fix available
+	FixableOnly bool `json:"fixableOnly" toml:"fixableOnly" yaml:"fixableOnly"`
+	// NotFixedSeverityLimit the severity limits for vulnerabilities without a fix, only used with FixableOnly
+	NotFixedSeverityLimit configServerityLimit `json:"notFixedSeverityLimit" toml:"notFixedSeverityLimit" yaml:"notFixedSeverityLimit"`
+}
+
+// configEPSSRiskAcceptance accepts vulnerabilities with an EPSS score below the threshold
+//
+// A non-zero Percentile replaces Score, the EPSS percentile is compared instead of the probability
 type configEPSSRiskAcceptance struct {
-	Enabled bool    `json:"enabled" toml:"enabled" yaml:"enabled"`
-	Score   float64 `json:"score"   toml:"score"   yaml:"score"`
+	Enabled    bool    `json:"enabled"    toml:"enabled"    yaml:"enabled"`
+	Score      float64 `json:"score"      toml:"score"      yaml:"score"`
+	Percentile float64 `json:"percentile" toml:"percentile" yaml:"percentile"`
 }
 type configCVERiskAcceptance struct {
 	Enabled bool        `json:"enabled" toml:"enabled" yaml:"enabled"`
 	CVEs    []configCVE `json:"cves"    toml:"cves"    yaml:"cves"`
+	// ExpirationWarningDays acceptances that expire within this many days are reported as warnings
+	ExpirationWarningDays uint `json:"expirationWarningDays" toml:"expirationWarningDays" yaml:"expirationWarningDays"`
 }
 type configServerityLimit struct {
 	Critical configLimit `json:"critical" toml:"critical" yaml:"critical"`
@@ -105,9 +176,50 @@ type configServerityLimit struct {
 	Low      configLimit `json:"low"      toml:"low"      yaml:"low"`
 }
 
+// configEPSSLimit fails on vulnerabilities with an EPSS score above the limit
+//
+// A non-zero Percentile replaces Score, the EPSS percentile is compared instead of the probability
 type configEPSSLimit struct {
+	Enabled    bool    `json:"enabled"    toml:"enabled"    yaml:"enabled"`
+	Score      float64 `json:"score"      toml:"score"      yaml:"score"`
+	Percentile float64 `json:"percentile" toml:"percentile" yaml:"percentile"`
+}
+
+// configRiskScoreLimit fails on vulnerabilities with a risk score above the limit
+//
+// The risk score is the weighted average of the severity, EPSS score, KEV catalog match
+// and CVSS base score, each normalized from 0 to 1, scaled to 0-10
+type configRiskScoreLimit struct {
+	Enabled bool              `json:"enabled" toml:"enabled" yaml:"enabled"`
+	Score   float64           `json:"score"   toml:"score"   yaml:"score"`
+	Weights configRiskWeights `json:"weights" toml:"weights" yaml:"weights"`
+}
+
+type configRiskWeights struct {
+	Severity float64 `json:"severity" toml:"severity" yaml:"severity"`
+	EPSS     float64 `json:"epss"     toml:"epss"     yaml:"epss"`
+	KEV      float64 `json:"kev"      toml:"kev"      yaml:"kev"`
+	CVSS     float64 `json:"cvss"     toml:"cvss"     yaml:"cvss"`
+}
+
+// configKEVLimit narrows the KEV limit to catalog entries with the metadata, used with kevLimitEnabled
+//
+// Catalog matches that don't apply are reported as warnings
+type configKEVLimit struct {
+	// PastDueOnly only fail on catalog entries past their dueDate
+	PastDueOnly bool `json:"pastDueOnly"    toml:"pastDueOnly"    yaml:"pastDueOnly"`
+	// RansomwareOnly only fail on catalog entries with known ransomware campaign use
+	RansomwareOnly bool `json:"ransomwareOnly" toml:"ransomwareOnly" yaml:"ransomwareOnly"`
+}
+
+// configCVSSLimit fails on vulnerabilities with a CVSS base score at or above the limit
+//
+// Version is the preferred CVSS version, ex. 3.1 or 3 for any v3 score.
+// The highest reported version is used if empty or if the preferred version isn't reported
+type configCVSSLimit struct {
 	Enabled bool    `json:"enabled" toml:"enabled" yaml:"enabled"`
 	Score   float64 `json:"score"   toml:"score"   yaml:"score"`
+	Version string  `json:"version" toml:"version" yaml:"version"`
 }
 
 type configCVELimit struct {
@@ -115,9 +227,25 @@ type configCVELimit struct {
 	CVEs    []configCVE `json:"cves"    toml:"cves"    yaml:"cves"`
 }
 
+// configCVE a denied or risk accepted CVE
+//
+// Risk acceptances can expire on a date formatted as YYYY-MM-DD.
+// Denied and accepted CVEs can be scoped to a package by name, version, version range,
+// package URL or ecosystem, empty scope fields match any package
 type configCVE struct {
-	ID       string `json:"id" toml:"id" yaml:"id"`
-	Metadata struct {
+	ID            string `json:"id"                      toml:"id"                      yaml:"id"`
+	Expires       string `json:"expires,omitempty"       toml:"expires,omitempty"       yaml:"expires,omitempty"`
+	Owner         string `json:"owner,omitempty"         toml:"owner,omitempty"         yaml:"owner,omitempty"`
+	Justification string `json:"justification,omitempty" toml:"justification,omitempty" yaml:"justification,omitempty"`
+	Package       string `json:"package,omitempty"       toml:"package,omitempty"       yaml:"package,omitempty"`
+	Version       string `json:"version,omitempty"       toml:"version,omitempty"       yaml:"version,omitempty"`
+	// VersionRange comma separated constraints, ex. ">=1.2.0, <2.0.0"
+	VersionRange string `json:"versionRange,omitempty" toml:"versionRange,omitempty" yaml:"versionRange,omitempty"`
+	// PURL matches the package URL without qualifiers, the version is optional, ex. pkg:npm/lodash
+	PURL string `json:"purl,omitempty" toml:"purl,omitempty" yaml:"purl,omitempty"`
+	// Ecosystem matches the package type or the package URL type, ex. npm, deb, go-module
+	Ecosystem string `json:"ecosystem,omitempty" toml:"ecosystem,omitempty" yaml:"ecosystem,omitempty"`
+	Metadata  struct {
 		Tags []string `json:"tags" toml:"tags" yaml:"tags"`
 	}
 }
@@ -175,21 +303,64 @@ func NewDefaultConfig() *Config {
 				},
 			},
 			EPSSLimit: configEPSSLimit{
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
+			},
+			CVSSLimit: configCVSSLimit{
 				Enabled: false,
 				Score:   0,
+				Version: "",
+			},
+			RiskScoreLimit: configRiskScoreLimit{
+				Enabled: false,
+				Score:   7,
+				Weights: configRiskWeights{
+					Severity: 2,
+					EPSS:     3,
+					KEV:      3,
+					CVSS:     2,
+				},
 			},
 			KEVLimitEnabled: false,
+			KEVLimit: configKEVLimit{
+				PastDueOnly:    false,
+				RansomwareOnly: false,
+			},
 			CVELimit: configCVELimit{
 				Enabled: false,
 				CVEs:    make([]configCVE, 0),
 			},
 			EPSSRiskAcceptance: configEPSSRiskAcceptance{
-				Enabled: false,
-				Score:   0,
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
 			},
 			CVERiskAcceptance: configCVERiskAcceptance{
-				Enabled: false,
-				CVEs:    make([]configCVE, 0),
+				Enabled:               false,
+				CVEs:                  make([]configCVE, 0),
+				ExpirationWarningDays: 14,
+			},
+			FixAvailability: configFixAvailability{
+				FixableOnly: false,
+				NotFixedSeverityLimit: configServerityLimit{
+					Critical: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					High: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Medium: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Low: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+				},
 			},
 		},
 		Cyclonedx: reportWithCVEs{
@@ -212,22 +383,163 @@ func NewDefaultConfig() *Config {
 				},
 			},
 			EPSSLimit: configEPSSLimit{
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
+			},
+			CVSSLimit: configCVSSLimit{
 				Enabled: false,
 				Score:   0,
+				Version: "",
+			},
+			RiskScoreLimit: configRiskScoreLimit{
+				Enabled: false,
+				Score:   7,
+				Weights: configRiskWeights{
+					Severity: 2,
+					EPSS:     3,
+					KEV:      3,
+					CVSS:     2,
+				},
 			},
 			KEVLimitEnabled: false,
+			KEVLimit: configKEVLimit{
+				PastDueOnly:    false,
+				RansomwareOnly: false,
+			},
 			CVELimit: configCVELimit{
 				Enabled: false,
 				CVEs:    make([]configCVE, 0),
 			},
 			EPSSRiskAcceptance: configEPSSRiskAcceptance{
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
+			},
+			CVERiskAcceptance: configCVERiskAcceptance{
+				Enabled:               false,
+				CVEs:                  make([]configCVE, 0),
+				ExpirationWarningDays: 14,
+			},
+			FixAvailability: configFixAvailability{
+				FixableOnly: false,
+				NotFixedSeverityLimit: configServerityLimit{
+					Critical: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					High: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Medium: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Low: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+				},
+			},
+		},
+		Trivy: reportWithCVEs{
+			SeverityLimit: configServerityLimit{
+				Critical: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+				High: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+				Medium: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+				Low: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+			},
+			EPSSLimit: configEPSSLimit{
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
+			},
+			CVSSLimit: configCVSSLimit{
 				Enabled: false,
 				Score:   0,
+				Version: "",
 			},
-			CVERiskAcceptance: configCVERiskAcceptance{
+			RiskScoreLimit: configRiskScoreLimit{
+				Enabled: false,
+				Score:   7,
+				Weights: configRiskWeights{
+					Severity: 2,
+					EPSS:     3,
+					KEV:      3,
+					CVSS:     2,
+				},
+			},
+			KEVLimitEnabled: false,
+			KEVLimit: configKEVLimit{
+				PastDueOnly:    false,
+				RansomwareOnly: false,
+			},
+			CVELimit: configCVELimit{
 				Enabled: false,
 				CVEs:    make([]configCVE, 0),
 			},
+			EPSSRiskAcceptance: configEPSSRiskAcceptance{
+				Enabled:    false,
+				Score:      0,
+				Percentile: 0,
+			},
+			CVERiskAcceptance: configCVERiskAcceptance{
+				Enabled:               false,
+				CVEs:                  make([]configCVE, 0),
+				ExpirationWarningDays: 14,
+			},
+			FixAvailability: configFixAvailability{
+				FixableOnly: false,
+				NotFixedSeverityLimit: configServerityLimit{
+					Critical: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					High: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Medium: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+					Low: configLimit{
+						Enabled: false,
+						Limit:   0,
+					},
+				},
+			},
+		},
+		SBOM: configSBOMReport{
+			PackageLimit: configSBOMPackageLimit{
+				Enabled:  false,
+				Packages: make([]configSBOMPackage, 0),
+			},
+			RequireVersion: false,
+			RequirePURL:    false,
+			ComponentLimit: configLimit{
+				Enabled: false,
+				Limit:   0,
+			},
+		},
+		Licenses: configLicenses{
+			Enabled:    false,
+			Allowed:    make([]string, 0),
+			Denied:     make([]string, 0),
+			Exceptions: make([]configSBOMPackage, 0),
 		},
 		Gitleaks: configGitleaksReport{
 			LimitEnabled: false,
@@ -237,6 +549,31 @@ func NewDefaultConfig() *Config {
 			FunctionThreshold: 0,
 			BranchThreshold:   0,
 		},
+		Sarif: configSarifReport{
+			Tools: []string{},
+			LevelLimit: configSarifLevelLimit{
+				Error: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+				Warning: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+				Note: configLimit{
+					Enabled: false,
+					Limit:   0,
+				},
+			},
+			RuleLimit: configSarifRuleIDs{
+				Enabled: false,
+				RuleIDs: []string{},
+			},
+			RuleRiskAcceptance: configSarifRuleIDs{
+				Enabled: false,
+				RuleIDs: []string{},
+			},
+		},
 	}
 }
 
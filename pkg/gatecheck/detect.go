@@ -0,0 +1,33 @@
+package gatecheck
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+// detectArtifactType the artifact type from the filename or the report content
+//
+// The filename is used when it names exactly one type, otherwise the content
+// is inspected. The returned reader replays the content for decoding
+func detectArtifactType(filename string, src io.Reader) (string, io.Reader, error) {
+	if artifactType := artifacts.TypeFromFilename(filename); artifactType != "" {
+		slog.Debug("artifact type from filename", "filename", filename, "type", artifactType)
+		return artifactType, src, nil
+	}
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return "", src, err
+	}
+
+	artifactType, err := artifacts.Detect(bytes.NewReader(content))
+	if err != nil {
+		return "", bytes.NewReader(content), err
+	}
+
+	slog.Debug("artifact type from content", "filename", filename, "type", artifactType)
+	return artifactType, bytes.NewReader(content), nil
+}
@@ -0,0 +1,92 @@
+package gatecheck
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+func Test_detectArtifactType(t *testing.T) {
+	testTable := []struct {
+		filename string
+		label    string
+		want     string
+	}{
+		{filename: "grype-report.json", label: "scan.json", want: artifacts.TypeGrype},
+		{filename: "grype-report.json", label: "grype-semgrep.json", want: artifacts.TypeGrype},
+		{filename: "cyclonedx-grype-sbom.json", label: "cyclonedx-grype-sbom.json", want: artifacts.TypeCyclonedx},
+		{filename: "cyclonedx-trivy-sbom.json", label: "sbom.json", want: artifacts.TypeCyclonedx},
+		{filename: "trivy-report.json", label: "results.json", want: artifacts.TypeTrivy},
+		{filename: "syft-report.json", label: "results.json", want: artifacts.TypeSyft},
+		{filename: "semgrep-sast-report.json", label: "results.json", want: artifacts.TypeSemgrep},
+		{filename: "gitleaks-report.json", label: "results.json", want: artifacts.TypeGitleaks},
+		{filename: "sarif-report.sarif", label: "results.json", want: artifacts.TypeSarif},
+		// the filename overrides the content
+		{filename: "grype-report.json", label: "semgrep.json", want: artifacts.TypeSemgrep},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.filename+"_"+testCase.label, func(t *testing.T) {
+			got, src, err := detectArtifactType(testCase.label, MustOpen("../../test/"+testCase.filename, t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != testCase.want {
+				t.Fatalf("want: %s got: %s", testCase.want, got)
+			}
+			// the content must still be readable after detection
+			if content, _ := io.ReadAll(src); len(content) == 0 {
+				t.Fatal("want: content after detection got: empty reader")
+			}
+		})
+	}
+
+	t.Run("text", func(t *testing.T) {
+		textTable := []struct {
+			content string
+			want    string
+		}{
+			{content: "TN:\nSF:main.go\nDA:1,1\nend_of_record\n", want: artifacts.TypeLCOV},
+			{content: `<?xml version="1.0" ?><coverage line-rate="0.5"><packages></packages></coverage>`, want: artifacts.TypeCobertura},
+			{content: `<?xml version="1.0"?><coverage generated="1"><project timestamp="1"></project></coverage>`, want: artifacts.TypeClover},
+			{content: "[]", want: artifacts.TypeGitleaks},
+		}
+		for _, testCase := range textTable {
+			got, _, err := detectArtifactType("report", strings.NewReader(testCase.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != testCase.want {
+				t.Fatalf("want: %s got: %s", testCase.want, got)
+			}
+		}
+	})
+
+	t.Run("bundle", func(t *testing.T) {
+		bundle := archive.NewBundle()
+		_ = bundle.AddFrom(MustOpen("../../test/grype-report.json", t), "scan.json", nil)
+		buf := new(bytes.Buffer)
+		if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := detectArtifactType("artifacts.tar.gz", buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != artifacts.TypeBundle {
+			t.Fatalf("want: %s got: %s", artifacts.TypeBundle, got)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, _, err := detectArtifactType("notes.txt", strings.NewReader(`{"hello": "world"}`))
+		if !errors.Is(err, artifacts.ErrUnknownType) {
+			t.Fatalf("want: %v got: %v", artifacts.ErrUnknownType, err)
+		}
+	})
+}
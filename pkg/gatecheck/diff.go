@@ -0,0 +1,401 @@
+package gatecheck
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/easy-up/go-coverage"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+// File diff status
+const (
+	FileAdded   = "added"
+	FileRemoved = "removed"
+	FileChanged = "changed"
+)
+
+// Finding diff status
+const (
+	FindingNew      = "new"
+	FindingResolved = "resolved"
+)
+
+// BundleDiff the changes between an old and a new bundle
+type BundleDiff struct {
+	Files   []FileDiff   `json:"files"`
+	Reports []ReportDiff `json:"reports"`
+}
+
+// FileDiff a file that was added, removed or changed by manifest digest
+type FileDiff struct {
+	Label     string `json:"label"`
+	Status    string `json:"status"`
+	OldDigest string `json:"oldDigest,omitempty"`
+	NewDigest string `json:"newDigest,omitempty"`
+}
+
+// ReportDiff the finding deltas of a report that changed between the bundles
+type ReportDiff struct {
+	Label    string           `json:"label"`
+	Artifact string           `json:"artifact"`
+	New      []Finding        `json:"new,omitempty"`
+	Resolved []Finding        `json:"resolved,omitempty"`
+	Coverage []CoverageChange `json:"coverage,omitempty"`
+}
+
+// CoverageChange the coverage percentage of a metric in both bundles
+type CoverageChange struct {
+	Metric string  `json:"metric"`
+	Old    float64 `json:"old"`
+	New    float64 `json:"new"`
+}
+
+// DiffBundles compare the files in two bundles by manifest digest
+//
+// Reports with the same label that changed are decoded for finding deltas,
+// vulnerabilities are matched by ID and package, Semgrep results by check ID and path
+// and Gitleaks secrets by fingerprint.
+// Every finding of an added report is new and every finding of a removed report is resolved
+func DiffBundles(oldSrc io.Reader, newSrc io.Reader) (*BundleDiff, error) {
+	oldBundle := archive.NewBundle()
+	if err := archive.UntarGzipBundle(oldSrc, oldBundle); err != nil {
+		slog.Error("decode old bundle", "error", err)
+		return nil, errors.New("cannot diff bundles: Old bundle decoding failed, See log for details")
+	}
+	newBundle := archive.NewBundle()
+	if err := archive.UntarGzipBundle(newSrc, newBundle); err != nil {
+		slog.Error("decode new bundle", "error", err)
+		return nil, errors.New("cannot diff bundles: New bundle decoding failed, See log for details")
+	}
+
+	diff := &BundleDiff{Files: []FileDiff{}, Reports: []ReportDiff{}}
+	oldFiles, newFiles := oldBundle.Manifest().Files, newBundle.Manifest().Files
+
+	for label, descriptor := range newFiles {
+		if label == archive.ManifestFilename {
+			continue
+		}
+		oldDescriptor, ok := oldFiles[label]
+		switch {
+		case !ok:
+			diff.Files = append(diff.Files, FileDiff{Label: label, Status: FileAdded, NewDigest: descriptor.Digest})
+		case oldDescriptor.Digest != descriptor.Digest:
+			diff.Files = append(diff.Files, FileDiff{Label: label, Status: FileChanged, OldDigest: oldDescriptor.Digest, NewDigest: descriptor.Digest})
+		}
+	}
+	for label, descriptor := range oldFiles {
+		if _, ok := newFiles[label]; !ok && label != archive.ManifestFilename {
+			diff.Files = append(diff.Files, FileDiff{Label: label, Status: FileRemoved, OldDigest: descriptor.Digest})
+		}
+	}
+	sort.Slice(diff.Files, func(i, j int) bool { return diff.Files[i].Label < diff.Files[j].Label })
+
+	for _, file := range diff.Files {
+		var reportDiff ReportDiff
+		var ok bool
+		var err error
+		switch file.Status {
+		case FileChanged:
+			reportDiff, ok, err = diffReports(file.Label, oldBundle.FileBytes(file.Label), newBundle.FileBytes(file.Label))
+		case FileAdded:
+			reportDiff, ok, err = diffReport(file.Label, newBundle.FileBytes(file.Label), FindingNew)
+		case FileRemoved:
+			reportDiff, ok, err = diffReport(file.Label, oldBundle.FileBytes(file.Label), FindingResolved)
+		}
+		if err != nil {
+			slog.Error("decode report for diff", "label", file.Label, "error", err)
+			return nil, errors.New("cannot diff bundles: Report decoding failed, See log for details")
+		}
+		if ok {
+			diff.Reports = append(diff.Reports, reportDiff)
+		}
+	}
+
+	return diff, nil
+}
+
+// diffReports the finding deltas, false if the report type isn't supported or changed
+func diffReports(label string, oldContent []byte, newContent []byte) (ReportDiff, bool, error) {
+	reportDiff := ReportDiff{Label: label}
+
+	oldType, oldReader, oldErr := detectArtifactType(label, bytes.NewReader(oldContent))
+	newType, newReader, newErr := detectArtifactType(label, bytes.NewReader(newContent))
+	if oldErr != nil || newErr != nil || oldType != newType {
+		slog.Debug("report type not supported or changed, skip finding diff", "label", label, "old_type", oldType, "new_type", newType)
+		return reportDiff, false, nil
+	}
+	reportDiff.Artifact = newType
+
+	switch newType {
+	case artifacts.TypeLCOV, artifacts.TypeCobertura, artifacts.TypeClover:
+		oldPercents, err := coveragePercents(oldReader, coverage.CoverageMode(newType))
+		if err != nil {
+			return reportDiff, false, err
+		}
+		newPercents, err := coveragePercents(newReader, coverage.CoverageMode(newType))
+		if err != nil {
+			return reportDiff, false, err
+		}
+		for idx, metric := range []string{"line", "function", "branch"} {
+			reportDiff.Coverage = append(reportDiff.Coverage, CoverageChange{Metric: metric, Old: oldPercents[idx], New: newPercents[idx]})
+		}
+		return reportDiff, true, nil
+	}
+
+	oldFindings, ok, err := findingsByKey(newType, oldReader)
+	if !ok || err != nil {
+		return reportDiff, ok, err
+	}
+	newFindings, _, err := findingsByKey(newType, newReader)
+	if err != nil {
+		return reportDiff, false, err
+	}
+
+	reportDiff.New = findingsNotIn(newFindings, oldFindings)
+	reportDiff.Resolved = findingsNotIn(oldFindings, newFindings)
+	return reportDiff, true, nil
+}
+
+// diffReport the findings of a report that was added or removed, all new or all resolved
+func diffReport(label string, content []byte, status string) (ReportDiff, bool, error) {
+	reportDiff := ReportDiff{Label: label}
+
+	artifactType, reader, err := detectArtifactType(label, bytes.NewReader(content))
+	if err != nil {
+		slog.Debug("report type not supported, skip finding diff", "label", label)
+		return reportDiff, false, nil
+	}
+	reportDiff.Artifact = artifactType
+
+	findings, ok, err := findingsByKey(artifactType, reader)
+	if !ok || err != nil {
+		return reportDiff, ok, err
+	}
+
+	if status == FindingResolved {
+		reportDiff.Resolved = findingsNotIn(findings, nil)
+	} else {
+		reportDiff.New = findingsNotIn(findings, nil)
+	}
+	return reportDiff, true, nil
+}
+
+// findingsByKey the findings of a report keyed like the baseline, false if the report type has no findings to compare
+func findingsByKey(artifactType string, src io.Reader) (map[string]Finding, bool, error) {
+	findings := make(map[string]Finding)
+
+	switch artifactType {
+	case artifacts.TypeGrype:
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return nil, true, err
+		}
+		for _, match := range report.Matches {
+			findings[vulnerabilityKey(match.Vulnerability.ID, match.Artifact.Name)] = grypeFinding(match)
+		}
+
+	case artifacts.TypeCyclonedx:
+		report := &artifacts.CyclonedxReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return nil, true, err
+		}
+		for idx, vulnerability := range report.Vulnerabilities {
+			// a vulnerability without an affected component is matched by ID
+			if len(report.AffectedComponents(idx)) == 0 {
+				findings[vulnerabilityKey(vulnerability.ID, "")] = cyclonedxFinding(vulnerability)
+			}
+			for _, component := range report.AffectedComponents(idx) {
+				finding := cyclonedxFinding(vulnerability)
+				finding.Package = component.Name
+				finding.Version = component.Version
+				findings[vulnerabilityKey(vulnerability.ID, component.Name)] = finding
+			}
+		}
+
+	case artifacts.TypeTrivy:
+		report := &artifacts.TrivyReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return nil, true, err
+		}
+		for _, vulnerability := range report.Vulnerabilities() {
+			findings[vulnerabilityKey(vulnerability.VulnerabilityID, vulnerability.PkgName)] = trivyFinding(vulnerability)
+		}
+
+	case artifacts.TypeSemgrep:
+		report := &artifacts.SemgrepReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return nil, true, err
+		}
+		for _, result := range report.Results {
+			findings[semgrepKey(result)] = semgrepFinding(result)
+		}
+
+	case artifacts.TypeGitleaks:
+		report := artifacts.GitLeaksReportMin{}
+		if err := json.NewDecoder(src).Decode(&report); err != nil {
+			return nil, true, err
+		}
+		for _, finding := range report {
+			key := gitleaksKey(finding)
+			if key == "" {
+				slog.Debug("no fingerprint, skip secret", "rule_id", finding.RuleID, "file", finding.File)
+				continue
+			}
+			findings[key] = gitleaksFinding(finding)
+		}
+
+	default:
+		slog.Debug("finding diff not supported for artifact type, skip", "filetype", artifactType)
+		return nil, false, nil
+	}
+	return findings, true, nil
+}
+
+// findingsNotIn the findings in a that are not in b, sorted by ID and package
+func findingsNotIn(a map[string]Finding, b map[string]Finding) []Finding {
+	findings := []Finding{}
+	for key, finding := range a {
+		if _, ok := b[key]; !ok {
+			findings = append(findings, finding)
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].ID != findings[j].ID {
+			return findings[i].ID < findings[j].ID
+		}
+		if findings[i].Package != findings[j].Package {
+			return findings[i].Package < findings[j].Package
+		}
+		return findings[i].Path < findings[j].Path
+	})
+	return findings
+}
+
+// coveragePercents the line, function and branch coverage percentages
+func coveragePercents(src io.Reader, coverageFormat coverage.CoverageMode) ([]float64, error) {
+	report, err := coverage.New(coverageFormat).ParseReader(src)
+	if err != nil {
+		return nil, err
+	}
+	percent := func(covered int, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return float64(covered) / float64(total) * 100
+	}
+	return []float64{
+		percent(report.CoveredLines, report.TotalLines),
+		percent(report.CoveredFunctions, report.TotalFunctions),
+		percent(report.CoveredBranches, report.TotalBranches),
+	}, nil
+}
+
+// EncodeBundleDiffTo write the diff as a table, markdown or json
+func EncodeBundleDiffTo(w io.Writer, diff *BundleDiff, format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	case "", "table", "ascii":
+		return writeBundleDiffTables(w, diff, false)
+	case "markdown", "md":
+		return writeBundleDiffTables(w, diff, true)
+	}
+	return fmt.Errorf("unsupported format '%s'", format)
+}
+
+func writeBundleDiffTables(w io.Writer, diff *BundleDiff, markdown bool) error {
+	sections := 0
+	heading := func(title string) {
+		if sections > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		sections++
+		if markdown {
+			_, _ = fmt.Fprintf(w, "## %s\n\n", title)
+			return
+		}
+		_, _ = fmt.Fprintln(w, title)
+	}
+	render := func(header []string, rows [][]string) {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(header)
+		table.SetAutoWrapText(false)
+		if markdown {
+			table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+			table.SetCenterSeparator("|")
+		}
+		table.AppendBulk(rows)
+		table.Render()
+	}
+
+	heading("Files")
+	if len(diff.Files) == 0 {
+		_, _ = fmt.Fprintln(w, "No file changes")
+		return nil
+	}
+	rows := [][]string{}
+	for _, file := range diff.Files {
+		rows = append(rows, []string{file.Label, file.Status, shortDigest(file.OldDigest), shortDigest(file.NewDigest)})
+	}
+	render([]string{"Label", "Status", "Old Digest", "New Digest"}, rows)
+
+	for _, report := range diff.Reports {
+		heading(fmt.Sprintf("%s (%s)", report.Label, report.Artifact))
+
+		if len(report.Coverage) > 0 {
+			rows := [][]string{}
+			for _, change := range report.Coverage {
+				rows = append(rows, []string{
+					change.Metric,
+					fmt.Sprintf("%0.2f%%", change.Old),
+					fmt.Sprintf("%0.2f%%", change.New),
+					fmt.Sprintf("%+0.2f%%", change.New-change.Old),
+				})
+			}
+			render([]string{"Coverage", "Old", "New", "Change"}, rows)
+			continue
+		}
+
+		if len(report.New) == 0 && len(report.Resolved) == 0 {
+			_, _ = fmt.Fprintln(w, "No finding changes")
+			continue
+		}
+		rows := [][]string{}
+		for _, status := range []string{FindingNew, FindingResolved} {
+			findings := report.New
+			if status == FindingResolved {
+				findings = report.Resolved
+			}
+			for _, finding := range findings {
+				location := finding.Path
+				if finding.Line > 0 {
+					location += ":" + strconv.Itoa(finding.Line)
+				}
+				rows = append(rows, []string{status, finding.ID, cmp.Or(finding.Severity, "-"), cmp.Or(finding.Package, "-"), cmp.Or(location, "-")})
+			}
+		}
+		render([]string{"Change", "ID", "Severity", "Package", "Location"}, rows)
+	}
+	return nil
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return cmp.Or(digest, "-")
+}
@@ -0,0 +1,145 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+func TestDiffBundles(t *testing.T) {
+	grypeReport := func(ids ...string) []byte {
+		report := artifacts.GrypeReportMin{}
+		for _, id := range ids {
+			match := artifacts.GrypeMatch{}
+			match.Vulnerability.ID = id
+			match.Artifact.Name = "openssl"
+			report.Matches = append(report.Matches, match)
+		}
+		content, _ := json.Marshal(report)
+		return content
+	}
+	lcovReport := func(linesHit int) []byte {
+		return []byte("SF:main.go\nLF:10\nLH:" + strconv.Itoa(linesHit) + "\nend_of_record\n")
+	}
+	bundleWith := func(files map[string][]byte) *bytes.Buffer {
+		bundle := archive.NewBundle()
+		for label, content := range files {
+			bundle.Add(content, label, nil)
+		}
+		buf := new(bytes.Buffer)
+		if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	cyclonedxReport := func(ids ...string) []byte {
+		report := artifacts.CyclonedxReportMin{}
+		for _, id := range ids {
+			report.Vulnerabilities = append(report.Vulnerabilities, artifacts.CyclonedxVulnerability{ID: id})
+		}
+		content, _ := json.Marshal(report)
+		return content
+	}
+
+	oldBundle := bundleWith(map[string][]byte{
+		"grype-report.json":     grypeReport("CVE-1", "CVE-2"),
+		"cyclonedx-report.json": cyclonedxReport("CVE-4"),
+		"lcov.info":             lcovReport(5),
+		"notes.txt":             []byte("unchanged"),
+		"trivy-report.json":     []byte(`{"Results": [{"Vulnerabilities": [{"VulnerabilityID": "CVE-5", "PkgName": "openssl"}]}]}`),
+	})
+	newBundle := bundleWith(map[string][]byte{
+		"grype-report.json":     grypeReport("CVE-2", "CVE-3"),
+		"cyclonedx-report.json": cyclonedxReport("CVE-4", "CVE-6"),
+		"lcov.info":             lcovReport(8),
+		"notes.txt":             []byte("unchanged"),
+		"gitleaks-report.json":  []byte(`[{"RuleID": "aws-key", "File": "a.env", "Fingerprint": "abc:a.env:aws-key:1"}]`),
+	})
+
+	diff, err := DiffBundles(oldBundle, newBundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFiles := map[string]string{
+		"cyclonedx-report.json": FileChanged,
+		"gitleaks-report.json":  FileAdded,
+		"grype-report.json":     FileChanged,
+		"lcov.info":             FileChanged,
+		"trivy-report.json":     FileRemoved,
+	}
+	if len(diff.Files) != len(wantFiles) {
+		t.Fatalf("want: %v got: %+v", wantFiles, diff.Files)
+	}
+	for _, file := range diff.Files {
+		if wantFiles[file.Label] != file.Status {
+			t.Fatalf("want: %s %s got: %s", file.Label, wantFiles[file.Label], file.Status)
+		}
+	}
+
+	if len(diff.Reports) != 5 {
+		t.Fatalf("want: cyclonedx, gitleaks, grype, lcov and trivy report diffs got: %+v", diff.Reports)
+	}
+
+	// a vulnerability without an affected component is matched by ID
+	cyclonedxDiff := diff.Reports[0]
+	if len(cyclonedxDiff.New) != 1 || cyclonedxDiff.New[0].ID != "CVE-6" || len(cyclonedxDiff.Resolved) != 0 {
+		t.Fatalf("want: new CVE-6 got: %+v", cyclonedxDiff)
+	}
+
+	// every finding of an added report is new and of a removed report is resolved
+	gitleaksDiff := diff.Reports[1]
+	if len(gitleaksDiff.New) != 1 || gitleaksDiff.New[0].ID != "aws-key" {
+		t.Fatalf("want: new aws-key secret got: %+v", gitleaksDiff)
+	}
+	trivyDiff := diff.Reports[4]
+	if len(trivyDiff.Resolved) != 1 || trivyDiff.Resolved[0].ID != "CVE-5" || len(trivyDiff.New) != 0 {
+		t.Fatalf("want: resolved CVE-5 got: %+v", trivyDiff)
+	}
+
+	grypeDiff := diff.Reports[2]
+	if len(grypeDiff.New) != 1 || grypeDiff.New[0].ID != "CVE-3" {
+		t.Fatalf("want: new CVE-3 got: %+v", grypeDiff.New)
+	}
+	if len(grypeDiff.Resolved) != 1 || grypeDiff.Resolved[0].ID != "CVE-1" {
+		t.Fatalf("want: resolved CVE-1 got: %+v", grypeDiff.Resolved)
+	}
+	coverageDiff := diff.Reports[3]
+	if len(coverageDiff.Coverage) != 3 || coverageDiff.Coverage[0].Old != 50 || coverageDiff.Coverage[0].New != 80 {
+		t.Fatalf("want: line coverage from 50 to 80 got: %+v", coverageDiff.Coverage)
+	}
+
+	for _, format := range []string{"table", "markdown", "json"} {
+		t.Run(format, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := EncodeBundleDiffTo(buf, diff, format); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), "CVE-3") || !strings.Contains(buf.String(), "trivy-report.json") {
+				t.Fatalf("want: CVE-3 and trivy-report.json in output got: %s", buf.String())
+			}
+		})
+	}
+
+	if err := EncodeBundleDiffTo(new(bytes.Buffer), diff, "xml"); err == nil {
+		t.Fatal("want: unsupported format error got: nil")
+	}
+}
+
+func Test_findingsByKey_gitleaks(t *testing.T) {
+	// secrets are matched by fingerprint like the baseline, a secret without one can't be matched
+	src := strings.NewReader(`[{"RuleID": "aws-key", "File": "a.env", "Fingerprint": "abc:a.env:aws-key:1"}, {"RuleID": "aws-key", "File": "b.env"}]`)
+	findings, supported, err := findingsByKey(artifacts.TypeGitleaks, src)
+	if err != nil || !supported {
+		t.Fatalf("want: supported got: %t %v", supported, err)
+	}
+	if _, ok := findings["abc:a.env:aws-key:1"]; !ok || len(findings) != 1 {
+		t.Fatalf("want: only the fingerprinted secret got: %+v", findings)
+	}
+}
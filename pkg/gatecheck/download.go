@@ -1,10 +1,14 @@
 package gatecheck
 
 import (
+	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/gatecheckdev/gatecheck/pkg/cache"
 	"github.com/gatecheckdev/gatecheck/pkg/epss"
 	"github.com/gatecheckdev/gatecheck/pkg/kev"
 )
@@ -12,12 +16,21 @@ import (
 type fetchOptions struct {
 	epssClient *http.Client
 	epssURL    string
+	epssDate   time.Time
 
 	kevClient *http.Client
 	kevURL    string
 
 	epssFile *os.File
 	kevFile  *os.File
+
+	baselineFile *os.File
+	baseline     *baseline
+
+	failFast bool
+
+	requireSignature bool
+	signatureKeyFile *os.File
 }
 
 func defaultOptions() *fetchOptions {
@@ -57,6 +70,15 @@ func WithKEVURL(url string) optionFunc {
 	}
 }
 
+// WithEPSSDate optionFunc that fetches the EPSS scores published on the date
+//
+// Will use the current scores if a zero time is passed
+func WithEPSSDate(date time.Time) optionFunc {
+	return func(o *fetchOptions) {
+		o.epssDate = date
+	}
+}
+
 func WithEPSSFile(epssFile *os.File) optionFunc {
 	return func(o *fetchOptions) {
 		o.epssFile = epssFile
@@ -69,15 +91,73 @@ func WithKEVFile(kevFile *os.File) optionFunc {
 	}
 }
 
+// WithFailFast optionFunc that stops validating an artifact at the first failed rule
+//
+// By default, every rule is evaluated and all failures are reported together
+func WithFailFast(failFast bool) optionFunc {
+	return func(o *fetchOptions) {
+		o.failFast = failFast
+	}
+}
+
+// WithBaselineFile optionFunc that accepts the findings in a previous report or bundle
+//
+// Limits are only applied to findings that are not in the baseline
+func WithBaselineFile(baselineFile *os.File) optionFunc {
+	return func(o *fetchOptions) {
+		o.baselineFile = baselineFile
+	}
+}
+
+// WithRequireSignature optionFunc that refuses unsigned bundles and bundles not signed by the public key
+//
+// Reports that are not in a bundle can't be signed and are refused.
+// Will not require a signature if nil is passed
+func WithRequireSignature(keyFile *os.File) optionFunc {
+	if keyFile == nil {
+		return func(_ *fetchOptions) {}
+	}
+
+	return func(o *fetchOptions) {
+		o.requireSignature = true
+		o.signatureKeyFile = keyFile
+	}
+}
+
+// WithCache optionFunc that reads EPSS and KEV data through the on-disk cache
+//
+// Will request the APIs directly if nil is passed
+func WithCache(c *cache.Cache) optionFunc {
+	if c == nil {
+		return func(_ *fetchOptions) {}
+	}
+
+	return func(o *fetchOptions) {
+		o.epssClient = c.Client(o.epssClient)
+		o.kevClient = c.Client(o.kevClient)
+	}
+}
+
 type optionFunc func(*fetchOptions)
 
+// checkOffline logs a request that failed because the data isn't cached in offline mode
+//
+// The epss and kev packages wrap the transport error, the cache is only known here
+func checkOffline(err error, source string) error {
+	if errors.Is(err, cache.ErrOffline) {
+		slog.Error(source+" data not available offline, run gatecheck download first", "error", err)
+	}
+	return err
+}
+
 func DownloadEPSS(w io.Writer, optionFuncs ...optionFunc) error {
 	options := defaultOptions()
 	for _, f := range optionFuncs {
 		f(options)
 	}
 
-	return epss.DownloadData(w, epss.WithClient(options.epssClient), epss.WithURL(options.epssURL))
+	err := epss.DownloadData(w, epss.WithClient(options.epssClient), epss.WithURL(options.epssURL), epss.WithDate(options.epssDate))
+	return checkOffline(err, "epss")
 }
 
 func DownloadKEV(w io.Writer, optionFuncs ...optionFunc) error {
@@ -86,5 +166,6 @@ func DownloadKEV(w io.Writer, optionFuncs ...optionFunc) error {
 		f(options)
 	}
 
-	return kev.DownloadData(w, kev.WithClient(options.kevClient), kev.WithURL(options.kevURL))
+	err := kev.DownloadData(w, kev.WithClient(options.kevClient), kev.WithURL(options.kevURL))
+	return checkOffline(err, "kev")
 }
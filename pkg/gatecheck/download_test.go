@@ -0,0 +1,20 @@
+package gatecheck
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/cache"
+)
+
+func TestDownload_offline(t *testing.T) {
+	offlineCache := &cache.Cache{Dir: t.TempDir(), Offline: true}
+
+	if err := DownloadEPSS(io.Discard, WithCache(offlineCache)); !errors.Is(err, cache.ErrOffline) {
+		t.Fatalf("want: %v got: %v", cache.ErrOffline, err)
+	}
+	if err := DownloadKEV(io.Discard, WithCache(offlineCache)); !errors.Is(err, cache.ErrOffline) {
+		t.Fatalf("want: %v got: %v", cache.ErrOffline, err)
+	}
+}
@@ -0,0 +1,106 @@
+package gatecheck
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JUnit XML data model, each artifact is a test suite and each rule is a test case
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// EncodeJUnitTo writes the validation result as a JUnit XML report
+//
+// Each artifact is a test suite and each evaluated rule is a test case.
+// Failed rules include the IDs of the offending findings
+func EncodeJUnitTo(w io.Writer, result *ValidationResult) error {
+	suites := junitTestSuites{Name: "gatecheck", Suites: make([]junitTestSuite, 0, len(result.Artifacts))}
+
+	for _, artifact := range result.Artifacts {
+		suite := junitTestSuite{Name: artifact.Label, TestCases: make([]junitTestCase, 0, len(artifact.Rules))}
+		if artifact.EPSS != nil {
+			suite.Properties = []junitProperty{
+				{Name: "epss.scoreDate", Value: artifact.EPSS.ScoreDate},
+				{Name: "epss.modelVersion", Value: artifact.EPSS.ModelVersion},
+			}
+		}
+		for _, rule := range artifact.Rules {
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("%s %s", rule.Artifact, rule.Rule),
+				ClassName: fmt.Sprintf("gatecheck.%s", artifact.Type),
+				SystemOut: strings.Join(rule.Warnings, "\n"),
+			}
+			switch rule.Status {
+			case RuleFail:
+				ids := make([]string, 0, len(rule.Findings))
+				for _, finding := range rule.Findings {
+					ids = append(ids, finding.ID)
+				}
+				testCase.Failure = &junitFailure{Message: rule.Message, Type: rule.Rule, Content: strings.Join(ids, "\n")}
+				suite.Failures++
+			case RuleSkipped:
+				testCase.Skipped = &junitSkipped{Message: "rule not enabled"}
+				suite.Skipped++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Tests = len(suite.TestCases)
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,39 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestEncodeJUnitTo(t *testing.T) {
+	result := &ValidationResult{Artifacts: []*ArtifactResult{
+		{
+			Label: "grype-report.json",
+			Type:  "grype",
+			Rules: []RuleResult{
+				newRuleResult(RuleCVEDeny, "grype").skip(),
+				newRuleResult(RuleKEVLimit, "grype"),
+				newRuleResult(RuleSeverityLimit, "grype").fail("Severity Limit Exceeded", Finding{ID: "cve-1"}, Finding{ID: "cve-2"}),
+			},
+		},
+	}}
+
+	buf := new(bytes.Buffer)
+	if err := EncodeJUnitTo(buf, result); err != nil {
+		t.Fatal(err)
+	}
+
+	suites := new(junitTestSuites)
+	if err := xml.Unmarshal(buf.Bytes(), suites); err != nil {
+		t.Fatal(err)
+	}
+
+	if suites.Tests != 3 || suites.Failures != 1 || suites.Skipped != 1 {
+		t.Fatalf("want: 3 tests 1 failure 1 skipped got: %d %d %d", suites.Tests, suites.Failures, suites.Skipped)
+	}
+	failure := suites.Suites[0].TestCases[2].Failure
+	if failure == nil || failure.Content != "cve-1\ncve-2" {
+		t.Fatalf("want: failure with finding ids got: %+v", failure)
+	}
+}
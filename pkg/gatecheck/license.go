@@ -0,0 +1,121 @@
+package gatecheck
+
+import (
+	"path"
+	"strings"
+)
+
+// placeholder license values used by SBOM generators when the license could not be determined
+var unknownLicenses = []string{"", "NOASSERTION", "NONE", "UNKNOWN"}
+
+// matchLicense true if the license matches any of the patterns
+//
+// patterns are case insensitive and support shell style wildcards, ex. GPL-*
+func matchLicense(patterns []string, license string) bool {
+	for _, pattern := range patterns {
+		if strings.EqualFold(pattern, license) {
+			return true
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(license)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// licensePermitted evaluates a single license ID against the allowed and denied lists
+//
+// A license with an exception, ex. "GPL-2.0-only WITH Classpath-exception-2.0",
+// can be allowed or denied explicitly, otherwise the license without the exception is evaluated
+func licensePermitted(config *Config, license string) bool {
+	switch {
+	case matchLicense(config.Licenses.Denied, license):
+		return false
+	case matchLicense(config.Licenses.Allowed, license):
+		return true
+	}
+	if id, _, found := strings.Cut(license, " WITH "); found {
+		return licensePermitted(config, id)
+	}
+	return len(config.Licenses.Allowed) == 0
+}
+
+// licenseExpressionPermitted evaluates an SPDX license expression
+//
+// Either side of an OR expression can be permitted, both sides of an AND expression must be permitted.
+// The whole expression can also be allowed or denied explicitly
+func licenseExpressionPermitted(config *Config, expression string) bool {
+	switch {
+	case matchLicense(config.Licenses.Denied, expression):
+		return false
+	case matchLicense(config.Licenses.Allowed, expression):
+		return true
+	}
+
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	parser := &licenseExpressionParser{
+		tokens: strings.Fields(expression),
+		permitted: func(license string) bool {
+			return licensePermitted(config, license)
+		},
+	}
+	return parser.parseOr()
+}
+
+// licenseExpressionParser a recursive descent parser for SPDX expressions
+//
+// Precedence from highest to lowest is WITH, AND, OR
+type licenseExpressionParser struct {
+	tokens    []string
+	pos       int
+	permitted func(string) bool
+}
+
+func (p *licenseExpressionParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *licenseExpressionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *licenseExpressionParser) parseOr() bool {
+	permitted := p.parseAnd()
+	for strings.EqualFold(p.peek(), "OR") {
+		p.pos++
+		right := p.parseAnd()
+		permitted = permitted || right
+	}
+	return permitted
+}
+
+func (p *licenseExpressionParser) parseAnd() bool {
+	permitted := p.parseLicense()
+	for strings.EqualFold(p.peek(), "AND") {
+		p.pos++
+		right := p.parseLicense()
+		permitted = permitted && right
+	}
+	return permitted
+}
+
+func (p *licenseExpressionParser) parseLicense() bool {
+	token := p.next()
+	if token == "(" {
+		permitted := p.parseOr()
+		if p.peek() == ")" {
+			p.pos++
+		}
+		return permitted
+	}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.pos++
+		token = token + " WITH " + p.next()
+	}
+	return p.permitted(token)
+}
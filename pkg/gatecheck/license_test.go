@@ -0,0 +1,41 @@
+package gatecheck
+
+import "testing"
+
+func Test_licenseExpressionPermitted(t *testing.T) {
+	config := new(Config)
+	config.Licenses.Denied = []string{"GPL-*", "AGPL-3.0-only"}
+
+	allowList := new(Config)
+	allowList.Licenses.Allowed = []string{"MIT", "Apache-2.0", "BSD-*", "GPL-2.0-only WITH Classpath-exception-2.0"}
+
+	testTable := []struct {
+		label      string
+		config     *Config
+		expression string
+		want       bool
+	}{
+		{label: "deny-wildcard", config: config, expression: "GPL-3.0-only", want: false},
+		{label: "deny-case-insensitive", config: config, expression: "agpl-3.0-only", want: false},
+		{label: "not-denied", config: config, expression: "MIT", want: true},
+		{label: "or-one-permitted", config: config, expression: "MIT OR GPL-2.0-or-later", want: true},
+		{label: "and-one-denied", config: config, expression: "MIT AND GPL-2.0-or-later", want: false},
+		{label: "parenthesis", config: config, expression: "(MIT OR GPL-3.0-only) AND (Apache-2.0 OR GPL-2.0-only)", want: true},
+		{label: "precedence", config: config, expression: "MIT AND GPL-3.0-only OR Apache-2.0", want: true},
+		{label: "with-exception-denied", config: config, expression: "GPL-2.0-only WITH Classpath-exception-2.0", want: false},
+		{label: "allow-list", config: allowList, expression: "Apache-2.0", want: true},
+		{label: "allow-list-wildcard", config: allowList, expression: "BSD-3-Clause", want: true},
+		{label: "allow-list-missing", config: allowList, expression: "ISC", want: false},
+		{label: "allow-list-with-exception", config: allowList, expression: "GPL-2.0-only WITH Classpath-exception-2.0", want: true},
+		{label: "allow-list-without-exception", config: allowList, expression: "GPL-2.0-only", want: false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			got := licenseExpressionPermitted(testCase.config, testCase.expression)
+			if got != testCase.want {
+				t.Fatalf("want: %t got: %t for %q", testCase.want, got, testCase.expression)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gatecheck
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,12 +18,15 @@ import (
 	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
 	"github.com/gatecheckdev/gatecheck/pkg/epss"
 	"github.com/gatecheckdev/gatecheck/pkg/format"
+	"github.com/gatecheckdev/gatecheck/pkg/kev"
 	"github.com/olekukonko/tablewriter"
 )
 
 type listOptions struct {
 	displayFormat string
 	epssData      *epss.Data
+	kevCatalog    *kev.Catalog
+	riskConfig    *Config
 }
 
 type ListOptionFunc func(*listOptions)
@@ -32,15 +37,22 @@ func WithDisplayFormat(displayFormat string) func(*listOptions) {
 	}
 }
 
-func WithEPSS(epssFile *os.File, epssURL string) (func(*listOptions), error) {
+// WithEPSS list vulnerabilities with EPSS scores from the file or the API
+//
+// optionFuncs apply to the API request, ex. WithCache
+func WithEPSS(epssFile *os.File, epssURL string, optionFuncs ...optionFunc) (func(*listOptions), error) {
 	data := &epss.Data{}
 	f := func(o *listOptions) {
 		o.epssData = data
 	}
 
 	if epssFile == nil {
-		err := epss.FetchData(data, epss.WithURL(epssURL))
-		return f, err
+		options := defaultOptions()
+		for _, optionFunc := range optionFuncs {
+			optionFunc(options)
+		}
+		err := epss.FetchData(data, epss.WithClient(options.epssClient), epss.WithURL(epssURL), epss.WithDate(options.epssDate))
+		return f, checkOffline(err, "epss")
 	}
 
 	err := epss.ParseEPSSDataCSV(epssFile, data)
@@ -48,45 +60,107 @@ func WithEPSS(epssFile *os.File, epssURL string) (func(*listOptions), error) {
 	return f, err
 }
 
+// WithKEV list the risk score with the KEV catalog from the file or the API
+//
+// optionFuncs apply to the API request, ex. WithCache
+func WithKEV(kevFile *os.File, kevURL string, optionFuncs ...optionFunc) (func(*listOptions), error) {
+	catalog := kev.NewCatalog()
+	f := func(o *listOptions) {
+		o.kevCatalog = catalog
+	}
+
+	if kevFile == nil {
+		options := defaultOptions()
+		for _, optionFunc := range optionFuncs {
+			optionFunc(options)
+		}
+		err := kev.FetchData(catalog, kev.WithClient(options.kevClient), kev.WithURL(cmp.Or(kevURL, options.kevURL)))
+		return f, checkOffline(err, "kev")
+	}
+
+	err := kev.DecodeData(kevFile, catalog)
+
+	return f, err
+}
+
+// WithRiskScore list vulnerabilities sorted by the risk score, highest first
+//
+// The weights are from the riskScoreLimit of each report type, the default config is used if nil is passed
+func WithRiskScore(config *Config) func(*listOptions) {
+	if config == nil {
+		config = NewDefaultConfig()
+	}
+	return func(o *listOptions) {
+		o.riskConfig = config
+	}
+}
+
 func List(dst io.Writer, src io.Reader, inputFilename string, options ...ListOptionFunc) error {
 	table := tablewriter.NewWriter(dst)
-	var err error
 	o := &listOptions{}
 	for _, f := range options {
 		f(o)
 	}
 
-	switch {
-	case strings.Contains(inputFilename, "grype"):
+	artifactType, src, err := detectArtifactType(inputFilename, src)
+	if err != nil {
+		slog.Error("unsupported file type, cannot be determined from filename or content", "filename", inputFilename, "error", err)
+		return errors.New("failed to list artifact content")
+	}
+
+	if strings.EqualFold(strings.TrimSpace(o.displayFormat), "sarif") {
+		slog.Debug("list as sarif", "filename", inputFilename)
+		return listAsSarif(dst, src, artifactType, inputFilename)
+	}
+
+	switch artifactType {
+	case artifacts.TypeSarif:
+		slog.Debug("list", "filename", inputFilename, "filetype", "sarif")
+		err = listSarifReport(table, src)
+
+	case artifacts.TypeGrype:
 		slog.Debug("list", "filename", inputFilename, "filetype", "grype")
-		if o.epssData != nil {
+		if o.riskConfig != nil {
+			err = listGrypeRiskScores(table, src, o)
+		} else if o.epssData != nil {
 			err = listGrypeWithEPSS(table, src, o.epssData)
 		} else {
 			err = ListGrypeReport(table, src)
 		}
 
-	case strings.Contains(inputFilename, "cyclonedx"):
+	case artifacts.TypeCyclonedx:
 		slog.Debug("list", "filename", inputFilename, "filetype", "cyclonedx")
-		if o.epssData != nil {
+		if o.riskConfig != nil {
+			err = listCyclonedxRiskScores(table, src, o)
+		} else if o.epssData != nil {
 			err = listCyclonedxWithEPSS(table, src, o.epssData)
 		} else {
 			err = ListCyclonedx(table, src)
 		}
 
-	case strings.Contains(inputFilename, "semgrep"):
+	case artifacts.TypeTrivy:
+		slog.Debug("list", "filename", inputFilename, "filetype", "trivy")
+		if o.riskConfig != nil {
+			err = listTrivyRiskScores(table, src, o)
+		} else if o.epssData != nil {
+			err = listTrivyWithEPSS(table, src, o.epssData)
+		} else {
+			err = ListTrivy(table, src)
+		}
+
+	case artifacts.TypeSemgrep:
 		slog.Debug("list", "filename", inputFilename, "filetype", "semgrep")
 		err = ListSemgrep(table, src)
 
-	case strings.Contains(inputFilename, "gitleaks"):
+	case artifacts.TypeGitleaks:
 		slog.Debug("list", "filename", inputFilename, "filetype", "gitleaks")
 		err = listGitleaks(table, src)
 
-	case strings.Contains(inputFilename, "syft"):
+	case artifacts.TypeSyft:
 		slog.Debug("list", "filename", inputFilename, "filetype", "syft")
-		slog.Warn("syft decoder is not supported yet")
-		return errors.New("syft not implemented yet")
+		err = listSyft(table, src)
 
-	case strings.Contains(inputFilename, "bundle") || strings.Contains(inputFilename, "gatecheck"):
+	case artifacts.TypeBundle:
 		slog.Debug("list", "filename", inputFilename, "filetype", "bundle")
 		bundle := archive.NewBundle()
 		if err = archive.UntarGzipBundle(src, bundle); err != nil {
@@ -95,12 +169,12 @@ func List(dst io.Writer, src io.Reader, inputFilename string, options ...ListOpt
 		_, err = fmt.Fprintln(dst, bundle.Content())
 		return err
 
-	case artifacts.IsCoverageReport(inputFilename):
+	case artifacts.TypeLCOV, artifacts.TypeCobertura, artifacts.TypeClover:
 		slog.Debug("list", "filename", inputFilename, "filetype", "coverage")
+		err = listCoverage(table, coverage.CoverageMode(artifactType), src)
 
-		err = listCoverage(table, inputFilename, src)
 	default:
-		slog.Error("unsupported file type, cannot be determined from filename", "filename", inputFilename)
+		slog.Error("unsupported file type", "filename", inputFilename, "filetype", artifactType)
 		return errors.New("failed to list artifact content")
 	}
 
@@ -120,12 +194,7 @@ func List(dst io.Writer, src io.Reader, inputFilename string, options ...ListOpt
 	return nil
 }
 
-func listCoverage(table *tablewriter.Table, inputFilename string, src io.Reader) error {
-	coverageFormat, err := artifacts.GetCoverageMode(inputFilename)
-	if err != nil {
-		return err
-	}
-
+func listCoverage(table *tablewriter.Table, coverageFormat coverage.CoverageMode, src io.Reader) error {
 	parser := coverage.New(coverageFormat)
 	report, err := parser.ParseReader(src)
 	if err != nil {
@@ -155,12 +224,18 @@ func ListGrypeReport(table *tablewriter.Table, src io.Reader) error {
 	matrix := format.NewSortableMatrix(make([][]string, 0), 0, catLess)
 
 	for _, item := range report.Matches {
-		row := []string{item.Vulnerability.Severity, item.Artifact.Name, item.Artifact.Version, item.Vulnerability.DataSource}
+		row := []string{
+			item.Vulnerability.Severity,
+			item.Artifact.Name,
+			item.Artifact.Version,
+			cmp.Or(item.Vulnerability.FixedIn(), "-"),
+			item.Vulnerability.DataSource,
+		}
 		matrix.Append(row)
 	}
 	sort.Sort(matrix)
 
-	header := []string{"Grype Severity", "Package", "Version", "Link"}
+	header := []string{"Grype Severity", "Package", "Version", "Fixed In", "Link"}
 
 	table.SetHeader(header)
 	matrix.Table(table)
@@ -201,6 +276,7 @@ func listGrypeWithEPSS(table *tablewriter.Table, src io.Reader, epssData *epss.D
 			prctl,
 			item.Artifact.Name,
 			item.Artifact.Version,
+			cmp.Or(item.Vulnerability.FixedIn(), "-"),
 			item.Vulnerability.DataSource,
 		}
 		matrix.Append(row)
@@ -213,6 +289,7 @@ func listGrypeWithEPSS(table *tablewriter.Table, src io.Reader, epssData *epss.D
 		"EPSS Prctl",
 		"Package",
 		"Version",
+		"Fixed In",
 		"Link",
 	}
 
@@ -231,6 +308,14 @@ func listGrypeWithEPSS(table *tablewriter.Table, src io.Reader, epssData *epss.D
 	return nil
 }
 
+// cyclonedxSeverity the highest rated severity, none if the vulnerability isn't rated
+func cyclonedxSeverity(vulnerability artifacts.CyclonedxVulnerability) string {
+	if len(vulnerability.Ratings) == 0 {
+		return "none"
+	}
+	return vulnerability.HighestSeverity()
+}
+
 func ListCyclonedx(table *tablewriter.Table, src io.Reader) error {
 	report := &artifacts.CyclonedxReportMin{}
 	slog.Debug("decode cyclonedx report", "format", "json")
@@ -238,23 +323,29 @@ func ListCyclonedx(table *tablewriter.Table, src io.Reader) error {
 		return err
 	}
 
+	if len(report.Vulnerabilities) == 0 && len(report.Components) > 0 {
+		slog.Debug("cyclonedx report has no vulnerabilities, list sbom components")
+		listCyclonedxComponents(table, report)
+		return nil
+	}
+
 	catLess := format.NewCatagoricLess([]string{"critical", "high", "medium", "low", "none"})
 	matrix := format.NewSortableMatrix(make([][]string, 0), 1, catLess)
 
 	link := "-"
 	for idx, vul := range report.Vulnerabilities {
-		severity := vul.HighestSeverity()
+		severity := cyclonedxSeverity(vul)
 		pkgs := report.AffectedPackages(idx)
 		if len(vul.Advisories) > 0 {
 			link = vul.Advisories[0].URL
 		}
 		// get the affected vulnerability
-		matrix.Append([]string{vul.ID, severity, pkgs, link})
+		matrix.Append([]string{vul.ID, severity, pkgs, cmp.Or(vul.FixedIn(), "-"), link})
 	}
 
 	sort.Sort(matrix)
 
-	header := []string{"Cyclonedx CVE ID", "Severity", "Package", "Link"}
+	header := []string{"Cyclonedx CVE ID", "Severity", "Package", "Fixed In", "Link"}
 	table.SetHeader(header)
 	matrix.Table(table)
 
@@ -275,6 +366,12 @@ func listCyclonedxWithEPSS(table *tablewriter.Table, src io.Reader, epssData *ep
 		return err
 	}
 
+	if len(report.Vulnerabilities) == 0 && len(report.Components) > 0 {
+		slog.Debug("cyclonedx report has no vulnerabilities, list sbom components")
+		listCyclonedxComponents(table, report)
+		return nil
+	}
+
 	catLess := format.NewCatagoricLess([]string{"critical", "high", "medium", "low", "info", "none", "unknown"})
 	matrix := format.NewSortableMatrix(make([][]string, 0), 1, catLess)
 
@@ -292,10 +389,11 @@ func listCyclonedxWithEPSS(table *tablewriter.Table, src io.Reader, epssData *ep
 		}
 		row := []string{
 			item.ID,
-			item.HighestSeverity(),
+			cyclonedxSeverity(item),
 			score,
 			prctl,
 			report.AffectedPackages(idx),
+			cmp.Or(item.FixedIn(), "-"),
 			link,
 		}
 		matrix.Append(row)
@@ -303,7 +401,7 @@ func listCyclonedxWithEPSS(table *tablewriter.Table, src io.Reader, epssData *ep
 
 	sort.Sort(matrix)
 
-	header := []string{"Cyclonedx CVE ID", "Severity", "EPSS Score", "EPSS Prctl", "affected Packages", "Link"}
+	header := []string{"Cyclonedx CVE ID", "Severity", "EPSS Score", "EPSS Prctl", "affected Packages", "Fixed In", "Link"}
 	table.SetHeader(header)
 	matrix.Table(table)
 
@@ -317,6 +415,154 @@ func listCyclonedxWithEPSS(table *tablewriter.Table, src io.Reader, epssData *ep
 	return nil
 }
 
+func ListTrivy(table *tablewriter.Table, src io.Reader) error {
+	report := &artifacts.TrivyReportMin{}
+	slog.Debug("decode trivy report", "format", "json")
+	if err := json.NewDecoder(src).Decode(&report); err != nil {
+		return err
+	}
+
+	catLess := format.NewCatagoricLess([]string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"})
+	matrix := format.NewSortableMatrix(make([][]string, 0), 1, catLess)
+
+	vulnerabilities := report.Vulnerabilities()
+	for _, item := range vulnerabilities {
+		row := []string{item.VulnerabilityID, item.Severity, item.PkgName, item.InstalledVersion, cmp.Or(item.FixedVersion, "-"), item.PrimaryURL}
+		matrix.Append(row)
+	}
+	sort.Sort(matrix)
+
+	header := []string{"Trivy CVE ID", "Severity", "Package", "Version", "Fixed In", "Link"}
+
+	table.SetHeader(header)
+	matrix.Table(table)
+
+	if len(vulnerabilities) == 0 {
+		footer := make([]string, len(header))
+		footer[len(header)-1] = "No Trivy Vulnerabilities"
+		table.SetFooter(footer)
+		table.SetBorder(false)
+	}
+
+	return nil
+}
+
+func listTrivyWithEPSS(table *tablewriter.Table, src io.Reader, epssData *epss.Data) error {
+	report := &artifacts.TrivyReportMin{}
+	slog.Debug("decode trivy report", "format", "json")
+	if err := json.NewDecoder(src).Decode(&report); err != nil {
+		return err
+	}
+
+	catLess := format.NewCatagoricLess([]string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"})
+	matrix := format.NewSortableMatrix(make([][]string, 0), 1, catLess)
+
+	vulnerabilities := report.Vulnerabilities()
+	for _, item := range vulnerabilities {
+		cve, ok := epssData.CVEs[item.VulnerabilityID]
+		score := "-"
+		prctl := "-"
+		if ok {
+			score = cve.EPSS
+			prctl = cve.Percentile
+		}
+
+		row := []string{
+			item.VulnerabilityID,
+			item.Severity,
+			score,
+			prctl,
+			item.PkgName,
+			item.InstalledVersion,
+			cmp.Or(item.FixedVersion, "-"),
+			item.PrimaryURL,
+		}
+		matrix.Append(row)
+	}
+
+	header := []string{
+		"Trivy CVE ID",
+		"Severity",
+		"EPSS Score",
+		"EPSS Prctl",
+		"Package",
+		"Version",
+		"Fixed In",
+		"Link",
+	}
+
+	sort.Sort(matrix)
+
+	table.SetHeader(header)
+	matrix.Table(table)
+
+	if len(vulnerabilities) == 0 {
+		footer := make([]string, len(header))
+		footer[len(header)-1] = "No Trivy Vulnerabilities"
+		table.SetFooter(footer)
+		table.SetBorder(false)
+	}
+
+	return nil
+}
+
+func listCyclonedxComponents(table *tablewriter.Table, report *artifacts.CyclonedxReportMin) {
+	nameLess := func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+	matrix := format.NewSortableMatrix(make([][]string, 0), 0, nameLess)
+
+	for _, component := range report.Components {
+		license := "-"
+		if ids := component.LicenseIDs(); len(ids) > 0 {
+			license = format.Summarize(strings.Join(ids, ", "), 50, format.ClipRight)
+		}
+		version := "-"
+		if component.Version != "" {
+			version = component.Version
+		}
+		matrix.Append([]string{component.Name, version, component.Type, license})
+	}
+
+	sort.Sort(matrix)
+
+	header := []string{"Cyclonedx Component", "Version", "Type", "License"}
+	table.SetHeader(header)
+	matrix.Table(table)
+}
+
+func listSyft(table *tablewriter.Table, src io.Reader) error {
+	report := &artifacts.SyftReportMin{}
+	slog.Debug("decode syft report", "format", "json")
+	if err := json.NewDecoder(src).Decode(report); err != nil {
+		return err
+	}
+
+	nameLess := func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+	matrix := format.NewSortableMatrix(make([][]string, 0), 0, nameLess)
+
+	for _, artifact := range report.Artifacts {
+		license := "-"
+		if len(artifact.Licenses) > 0 {
+			license = format.Summarize(strings.Join(artifact.Licenses, ", "), 50, format.ClipRight)
+		}
+		matrix.Append([]string{artifact.Name, cmp.Or(artifact.Version, "-"), artifact.Type, license})
+	}
+
+	sort.Sort(matrix)
+
+	header := []string{"Syft Package", "Version", "Type", "License"}
+	table.SetHeader(header)
+	matrix.Table(table)
+
+	if len(report.Artifacts) == 0 {
+		footer := make([]string, len(header))
+		footer[len(header)-1] = "No Syft Packages"
+		table.SetFooter(footer)
+		table.SetBorder(false)
+	}
+
+	return nil
+}
+
 func ListSemgrep(table *tablewriter.Table, src io.Reader) error {
 	report := &artifacts.SemgrepReportMin{}
 
@@ -390,3 +636,173 @@ func listGitleaks(table *tablewriter.Table, src io.Reader) error {
 
 	return nil
 }
+
+func listSarifReport(table *tablewriter.Table, src io.Reader) error {
+	report := &artifacts.SarifReportMin{}
+	slog.Debug("decode sarif report", "format", "json")
+	if err := json.NewDecoder(src).Decode(report); err != nil {
+		return err
+	}
+
+	catLess := format.NewCatagoricLess([]string{"error", "warning", "note", "none", "suppressed"})
+	matrix := format.NewSortableMatrix(make([][]string, 0), 2, catLess)
+
+	count := 0
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			uri, line := result.Location()
+			location := "-"
+			if uri != "" {
+				location = fmt.Sprintf("%s:%d", format.Summarize(uri, 50, format.ClipMiddle), line)
+			}
+			level := run.ResultLevel(result)
+			if result.IsSuppressed() {
+				level = "suppressed"
+			}
+			row := []string{
+				run.Tool.Driver.Name,
+				format.Summarize(result.RuleID, 50, format.ClipMiddle),
+				level,
+				location,
+			}
+			matrix.Append(row)
+			count++
+		}
+	}
+
+	sort.Sort(matrix)
+
+	header := []string{"Tool", "SARIF Rule ID", "Level", "Location"}
+	table.SetHeader(header)
+	matrix.Table(table)
+
+	if count == 0 {
+		footer := make([]string, len(header))
+		footer[len(header)-1] = "No SARIF Results"
+		table.SetFooter(footer)
+		table.SetBorder(false)
+	}
+
+	return nil
+}
+
+// riskRow a vulnerability listed by risk score
+type riskRow struct {
+	id       string
+	severity string
+	pkg      string
+	version  string
+	fixedIn  string
+	factors  riskFactors
+}
+
+func listGrypeRiskScores(table *tablewriter.Table, src io.Reader, o *listOptions) error {
+	report := &artifacts.GrypeReportMin{}
+	slog.Debug("decode grype report", "format", "json")
+	if err := json.NewDecoder(src).Decode(&report); err != nil {
+		return err
+	}
+
+	rows := make([]riskRow, 0, len(report.Matches))
+	for _, item := range report.Matches {
+		rows = append(rows, riskRow{
+			id:       item.Vulnerability.ID,
+			severity: item.Vulnerability.Severity,
+			pkg:      item.Artifact.Name,
+			version:  item.Artifact.Version,
+			fixedIn:  item.Vulnerability.FixedIn(),
+			factors:  newRiskFactors(item.Vulnerability.ID, item.Vulnerability.Severity, item.Vulnerability.CVSSScores(), o.kevCatalog, o.epssData),
+		})
+	}
+	listRiskScores(table, "Grype", rows, o.riskConfig.Grype.RiskScoreLimit.Weights)
+	return nil
+}
+
+func listCyclonedxRiskScores(table *tablewriter.Table, src io.Reader, o *listOptions) error {
+	report := &artifacts.CyclonedxReportMin{}
+	slog.Debug("decode cyclonedx report", "format", "json")
+	if err := json.NewDecoder(src).Decode(&report); err != nil {
+		return err
+	}
+
+	rows := make([]riskRow, 0, len(report.Vulnerabilities))
+	for idx, vul := range report.Vulnerabilities {
+		rows = append(rows, riskRow{
+			id:       vul.ID,
+			severity: cyclonedxSeverity(vul),
+			pkg:      report.AffectedPackages(idx),
+			fixedIn:  vul.FixedIn(),
+			factors:  newRiskFactors(vul.ID, cyclonedxSeverity(vul), vul.CVSSScores(), o.kevCatalog, o.epssData),
+		})
+	}
+	listRiskScores(table, "Cyclonedx", rows, o.riskConfig.Cyclonedx.RiskScoreLimit.Weights)
+	return nil
+}
+
+func listTrivyRiskScores(table *tablewriter.Table, src io.Reader, o *listOptions) error {
+	report := &artifacts.TrivyReportMin{}
+	slog.Debug("decode trivy report", "format", "json")
+	if err := json.NewDecoder(src).Decode(&report); err != nil {
+		return err
+	}
+
+	vulnerabilities := report.Vulnerabilities()
+	rows := make([]riskRow, 0, len(vulnerabilities))
+	for _, item := range vulnerabilities {
+		rows = append(rows, riskRow{
+			id:       item.VulnerabilityID,
+			severity: item.Severity,
+			pkg:      item.PkgName,
+			version:  item.InstalledVersion,
+			fixedIn:  item.FixedVersion,
+			factors:  newRiskFactors(item.VulnerabilityID, item.Severity, item.CVSSScores(), o.kevCatalog, o.epssData),
+		})
+	}
+	listRiskScores(table, "Trivy", rows, o.riskConfig.Trivy.RiskScoreLimit.Weights)
+	return nil
+}
+
+func listRiskScores(table *tablewriter.Table, title string, rows []riskRow, weights configRiskWeights) {
+	// highest risk first
+	slices.SortStableFunc(rows, func(a, b riskRow) int {
+		return cmp.Compare(b.factors.score(weights), a.factors.score(weights))
+	})
+
+	header := []string{
+		title + " CVE ID",
+		"Severity",
+		"Risk Score",
+		"EPSS Score",
+		"KEV",
+		"CVSS",
+		"Package",
+		"Version",
+		"Fixed In",
+	}
+	table.SetHeader(header)
+
+	for _, row := range rows {
+		kevMatch := "-"
+		if row.factors.KEV > 0 {
+			kevMatch = "yes"
+		}
+		table.Append([]string{
+			row.id,
+			row.severity,
+			fmt.Sprintf("%.1f", row.factors.score(weights)),
+			fmt.Sprintf("%.5f", row.factors.EPSS),
+			kevMatch,
+			fmt.Sprintf("%.1f", row.factors.CVSS*10),
+			cmp.Or(row.pkg, "-"),
+			cmp.Or(row.version, "-"),
+			cmp.Or(row.fixedIn, "-"),
+		})
+	}
+
+	if len(rows) == 0 {
+		footer := make([]string, len(header))
+		footer[len(header)-1] = fmt.Sprintf("No %s Vulnerabilities", title)
+		table.SetFooter(footer)
+		table.SetBorder(false)
+	}
+}
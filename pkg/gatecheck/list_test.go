@@ -0,0 +1,27 @@
+package gatecheck
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestList_syft(t *testing.T) {
+	dst := new(bytes.Buffer)
+	if err := List(dst, MustOpen("../../test/syft-report.json", t), "syft-report.json"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"SYFT PACKAGE", "bash", "5.1-6ubuntu1", "GPL-3.0-only"} {
+		if !strings.Contains(dst.String(), want) {
+			t.Fatalf("want: %s in output got:\n%s", want, dst.String())
+		}
+	}
+
+	dst.Reset()
+	if err := List(dst, strings.NewReader(`{"artifacts": [], "descriptor": {"name": "syft"}}`), "syft-report.json"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dst.String(), "NO SYFT PACKAGES") {
+		t.Fatalf("want: no packages footer got:\n%s", dst.String())
+	}
+}
@@ -0,0 +1,246 @@
+package gatecheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gatecheckdev/gatecheck/pkg/epss"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleStatus the outcome of a single validation rule
+type RuleStatus string
+
+const (
+	RulePass    RuleStatus = "pass"
+	RuleFail    RuleStatus = "fail"
+	RuleSkipped RuleStatus = "skipped"
+)
+
+// Rule names used in validation results
+const (
+	RuleCVEDeny              = "cve-deny"
+	RuleCVEAllow             = "cve-allow"
+	RuleKEVLimit             = "kev-limit"
+	RuleEPSSAllow            = "epss-allow"
+	RuleEPSSLimit            = "epss-limit"
+	RuleCVSSLimit            = "cvss-limit"
+	RuleRiskScoreLimit       = "risk-score-limit"
+	RuleSeverityLimit        = "severity-limit"
+	RuleImpactRiskAcceptance = "impact-risk-acceptance"
+	RuleSecretsLimit         = "secrets-limit"
+	RuleLineCoverage         = "line-coverage"
+	RuleFunctionCoverage     = "function-coverage"
+	RuleBranchCoverage       = "branch-coverage"
+	RuleRuleDeny             = "rule-deny"
+	RuleRuleAllow            = "rule-allow"
+	RulePackageDeny          = "package-deny"
+	RuleRequireVersion       = "require-version"
+	RuleRequirePURL          = "require-purl"
+	RuleComponentLimit       = "component-limit"
+	RuleLicense              = "license"
+	RuleBaseline             = "baseline"
+	RuleFixAvailable         = "fix-available"
+	RuleNotFixedSeverity     = "not-fixed-severity-limit"
+)
+
+// artifactTitles used as the prefix for validation error messages
+var artifactTitles = map[string]string{
+	"grype":     "Grype",
+	"cyclonedx": "CycloneDx",
+	"trivy":     "Trivy",
+	"semgrep":   "Semgrep",
+	"gitleaks":  "Gitleaks",
+	"coverage":  "Coverage",
+	"sarif":     "SARIF",
+	"syft":      "Syft",
+}
+
+// ValidationResult is the outcome of every rule evaluated during validation
+//
+// A single report produces one artifact result, a bundle produces one per file
+type ValidationResult struct {
+	Artifacts []*ArtifactResult `json:"artifacts" yaml:"artifacts"`
+}
+
+// ArtifactResult the rule outcomes for a single report
+type ArtifactResult struct {
+	// Label is the filename or the bundle file label
+	Label string `json:"label" yaml:"label"`
+	Type  string `json:"type"  yaml:"type"`
+	// Counts the number of findings by severity in the report before any rules are applied
+	Counts map[string]int `json:"counts,omitempty" yaml:"counts,omitempty"`
+	// EPSS the scores used by the EPSS rules, empty if EPSS data wasn't loaded
+	EPSS  *EPSSSource  `json:"epss,omitempty" yaml:"epss,omitempty"`
+	Rules []RuleResult `json:"rules"          yaml:"rules"`
+}
+
+// EPSSSource identifies the EPSS data so a validation can be reproduced with the same scores
+type EPSSSource struct {
+	ScoreDate    string `json:"scoreDate"    yaml:"scoreDate"`
+	ModelVersion string `json:"modelVersion" yaml:"modelVersion"`
+}
+
+func newEPSSSource(data *epss.Data) *EPSSSource {
+	if data == nil || data.ScoreDate.IsZero() {
+		return nil
+	}
+	return &EPSSSource{ScoreDate: data.ScoreDate.Format(epss.DateLayout), ModelVersion: data.ModelVersion}
+}
+
+// RuleResult the outcome of a single rule evaluated against a single artifact
+type RuleResult struct {
+	Rule     string     `json:"rule"               yaml:"rule"`
+	Artifact string     `json:"artifact"           yaml:"artifact"`
+	Status   RuleStatus `json:"status"             yaml:"status"`
+	Message  string     `json:"message,omitempty"  yaml:"message,omitempty"`
+	// Findings that caused the rule to fail
+	Findings []Finding `json:"findings,omitempty" yaml:"findings,omitempty"`
+	// Accepted findings that were risk accepted by the rule and removed from subsequent rules
+	Accepted []Finding `json:"accepted,omitempty" yaml:"accepted,omitempty"`
+	// SetAside findings removed from subsequent rules to be evaluated separately, they aren't risk accepted
+	SetAside []Finding `json:"setAside,omitempty" yaml:"setAside,omitempty"`
+	// Thresholds are the configured values used to evaluate the rule
+	Thresholds map[string]any `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	// Warnings don't fail the rule but need attention, like risk acceptances close to expiring
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// Finding a single item from a report referenced by a rule result
+type Finding struct {
+	ID       string `json:"id"                 yaml:"id"`
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Package  string `json:"package,omitempty"  yaml:"package,omitempty"`
+	Version  string `json:"version,omitempty"  yaml:"version,omitempty"`
+	FixedIn  string `json:"fixedIn,omitempty"  yaml:"fixedIn,omitempty"`
+	Path     string `json:"path,omitempty"     yaml:"path,omitempty"`
+	Line     int    `json:"line,omitempty"     yaml:"line,omitempty"`
+	Detail   string `json:"detail,omitempty"   yaml:"detail,omitempty"`
+}
+
+func newRuleResult(rule string, artifact string) RuleResult {
+	return RuleResult{Rule: rule, Artifact: artifact, Status: RulePass}
+}
+
+func (r RuleResult) skip() RuleResult {
+	r.Status = RuleSkipped
+	return r
+}
+
+func (r RuleResult) fail(message string, findings ...Finding) RuleResult {
+	r.Status = RuleFail
+	r.Message = message
+	r.Findings = append(r.Findings, findings...)
+	return r
+}
+
+// Passed is true unless the rule failed, skipped rules are considered passing
+func (r RuleResult) Passed() bool {
+	return r.Status != RuleFail
+}
+
+func (r RuleResult) accept(findings ...Finding) RuleResult {
+	r.Accepted = append(r.Accepted, findings...)
+	return r
+}
+
+func (r RuleResult) setAside(findings ...Finding) RuleResult {
+	r.SetAside = append(r.SetAside, findings...)
+	return r
+}
+
+func (r RuleResult) warn(warnings ...string) RuleResult {
+	r.Warnings = append(r.Warnings, warnings...)
+	return r
+}
+
+func newArtifactResult(artifactType string) *ArtifactResult {
+	return &ArtifactResult{Type: artifactType, Counts: make(map[string]int), Rules: make([]RuleResult, 0)}
+}
+
+func (a *ArtifactResult) add(r RuleResult) RuleResult {
+	a.Rules = append(a.Rules, r)
+	return r
+}
+
+// Passed is true if no rule failed for this artifact
+func (a *ArtifactResult) Passed() bool {
+	for _, rule := range a.Rules {
+		if !rule.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Err joins a validation error for each failed rule, nil if all rules passed
+func (a *ArtifactResult) Err() error {
+	var errs error
+	title, ok := artifactTitles[a.Type]
+	if !ok {
+		title = a.Type
+	}
+	for _, rule := range a.Rules {
+		if rule.Passed() {
+			continue
+		}
+		errs = errors.Join(errs, newValidationErr(fmt.Sprintf("%s: %s", title, rule.Message)))
+	}
+	return errs
+}
+
+// Passed is true if every artifact passed validation
+func (v *ValidationResult) Passed() bool {
+	for _, artifact := range v.Artifacts {
+		if !artifact.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures all of the failed rules across every artifact
+func (v *ValidationResult) Failures() []RuleResult {
+	failures := make([]RuleResult, 0)
+	for _, artifact := range v.Artifacts {
+		for _, rule := range artifact.Rules {
+			if !rule.Passed() {
+				failures = append(failures, rule)
+			}
+		}
+	}
+	return failures
+}
+
+// Err joins the errors for each artifact, nil if validation passed
+func (v *ValidationResult) Err() error {
+	var errs error
+	for _, artifact := range v.Artifacts {
+		errs = errors.Join(errs, artifact.Err())
+	}
+	return errs
+}
+
+// EncodeValidationResultTo writes the validation result as a json or yaml document
+func EncodeValidationResultTo(w io.Writer, result *ValidationResult, format string) error {
+	var encoder interface {
+		Encode(any) error
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		encoder = enc
+	case "yaml", "yml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		encoder = enc
+	default:
+		return fmt.Errorf("unsupported format '%s'", format)
+	}
+
+	return encoder.Encode(result)
+}
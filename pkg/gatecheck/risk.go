@@ -0,0 +1,114 @@
+package gatecheck
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+	"github.com/gatecheckdev/gatecheck/pkg/epss"
+	"github.com/gatecheckdev/gatecheck/pkg/kev"
+)
+
+// Risk Score
+//
+// Each rule decides on its own, the risk score combines the severity, EPSS, KEV and CVSS
+// of a single vulnerability so one limit can prioritize across all of them.
+// Every factor is normalized from 0 to 1 and the score is the weighted average scaled to 0-10
+
+// severityRiskFactors the normalized severity factor
+var severityRiskFactors = map[string]float64{
+	"critical": 1,
+	"high":     0.75,
+	"medium":   0.5,
+	"low":      0.25,
+}
+
+// riskFactors the normalized inputs for the risk score of a single vulnerability
+type riskFactors struct {
+	Severity float64
+	EPSS     float64
+	KEV      float64
+	CVSS     float64
+}
+
+func newRiskFactors(id string, severity string, cvssScores []artifacts.CVSSScore, catalog *kev.Catalog, data *epss.Data) riskFactors {
+	factors := riskFactors{Severity: severityRiskFactors[strings.ToLower(severity)]}
+	if data != nil {
+		if epssCVE, ok := data.CVEs[id]; ok {
+			factors.EPSS = epssCVE.EPSSValue()
+		}
+	}
+	if catalog != nil {
+		if _, ok := catalog.Lookup(id); ok {
+			factors.KEV = 1
+		}
+	}
+	if score, ok := artifacts.SelectCVSSScore(cvssScores, ""); ok {
+		factors.CVSS = score.BaseScore / 10
+	}
+	return factors
+}
+
+// score the weighted average of the factors from 0 to 10, 0 if every weight is 0
+func (f riskFactors) score(weights configRiskWeights) float64 {
+	total := weights.Severity + weights.EPSS + weights.KEV + weights.CVSS
+	if total <= 0 {
+		return 0
+	}
+	weighted := weights.Severity*f.Severity + weights.EPSS*f.EPSS + weights.KEV*f.KEV + weights.CVSS*f.CVSS
+	return 10 * weighted / total
+}
+
+func (f riskFactors) detail(weights configRiskWeights) string {
+	return fmt.Sprintf("risk score %.1f (severity %.2f, epss %.2f, kev %.0f, cvss %.2f)",
+		f.score(weights), f.Severity, f.EPSS, f.KEV, f.CVSS)
+}
+
+func riskScoreThresholds(limit configRiskScoreLimit) map[string]any {
+	return map[string]any{
+		"score":           limit.Score,
+		"severity_weight": limit.Weights.Severity,
+		"epss_weight":     limit.Weights.EPSS,
+		"kev_weight":      limit.Weights.KEV,
+		"cvss_weight":     limit.Weights.CVSS,
+	}
+}
+
+// riskDataNeeded the risk score limit has a weight for data loaded from the KEV catalog or EPSS API
+func riskDataNeeded(config *Config) (kevNeeded bool, epssNeeded bool) {
+	for _, limit := range []configRiskScoreLimit{config.Grype.RiskScoreLimit, config.Cyclonedx.RiskScoreLimit, config.Trivy.RiskScoreLimit} {
+		if !limit.Enabled {
+			continue
+		}
+		kevNeeded = kevNeeded || limit.Weights.KEV > 0
+		epssNeeded = epssNeeded || limit.Weights.EPSS > 0
+	}
+	return kevNeeded, epssNeeded
+}
+
+func ruleRiskScoreLimit(config reportWithCVEs, report *vulnerabilityReport, catalog *kev.Catalog, data *epss.Data) RuleResult {
+	result := newRuleResult(RuleRiskScoreLimit, report.Artifact)
+	limit := config.RiskScoreLimit
+	if !limit.Enabled {
+		slog.Debug("risk score limit not enabled", "artifact", report.Artifact)
+		return result.skip()
+	}
+	result.Thresholds = riskScoreThresholds(limit)
+
+	badCVEs := make([]Finding, 0)
+	for _, vulnerability := range report.Vulnerabilities {
+		factors := newRiskFactors(vulnerability.ID, vulnerability.Severity, vulnerability.CVSSScores, catalog, data)
+		if factors.score(limit.Weights) <= limit.Score {
+			continue
+		}
+		slog.Warn("risk score limit violation", "artifact", report.Artifact, "cve_id", vulnerability.ID,
+			"package", vulnerability.Finding.Package, "risk_score", factors.score(limit.Weights))
+		badCVEs = append(badCVEs, vulnerability.findingWithDetail(factors.detail(limit.Weights)))
+	}
+	if len(badCVEs) > 0 {
+		slog.Error("cve(s) with risk scores over limit", "artifact", report.Artifact, "over_limit_cves", len(badCVEs), "risk_score_limit", limit.Score)
+		return result.fail("Risk Score Limit Exceeded", badCVEs...)
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+	"github.com/gatecheckdev/gatecheck/pkg/epss"
+	"github.com/gatecheckdev/gatecheck/pkg/kev"
+)
+
+func riskTestData() (*artifacts.TrivyReportMin, *kev.Catalog, *epss.Data) {
+	report := &artifacts.TrivyReportMin{Results: []artifacts.TrivyResult{{Vulnerabilities: []artifacts.TrivyVulnerability{
+		{VulnerabilityID: "cve-low", Severity: "LOW", CVSS: map[string]artifacts.TrivyCVSS{"nvd": {V3Score: 3.0}}},
+		{VulnerabilityID: "cve-critical", Severity: "CRITICAL", CVSS: map[string]artifacts.TrivyCVSS{"nvd": {V3Score: 9.8}}},
+		{VulnerabilityID: "cve-exploited", Severity: "MEDIUM", CVSS: map[string]artifacts.TrivyCVSS{"nvd": {V3Score: 6.5}}},
+	}}}}
+	catalog := &kev.Catalog{Vulnerabilities: []kev.Vulnerability{{CveID: "cve-exploited"}}}
+	data := &epss.Data{CVEs: map[string]epss.CVE{
+		"cve-low":       {EPSS: "0.01"},
+		"cve-critical":  {EPSS: "0.02"},
+		"cve-exploited": {EPSS: "0.95"},
+	}}
+	return report, catalog, data
+}
+
+func Test_riskFactors_score(t *testing.T) {
+	factors := riskFactors{Severity: 1, EPSS: 0.5, KEV: 1, CVSS: 0.9}
+
+	testTable := []struct {
+		label   string
+		weights configRiskWeights
+		want    float64
+	}{
+		{label: "severity-only", weights: configRiskWeights{Severity: 1}, want: 10},
+		{label: "epss-only", weights: configRiskWeights{EPSS: 1}, want: 5},
+		{label: "even", weights: configRiskWeights{Severity: 1, EPSS: 1, KEV: 1, CVSS: 1}, want: 8.5},
+		{label: "no-weights", weights: configRiskWeights{}, want: 0},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			if got := factors.score(testCase.weights); got != testCase.want {
+				t.Fatalf("want: %v got: %v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func Test_ruleRiskScoreLimit(t *testing.T) {
+	report, catalog, data := riskTestData()
+
+	testTable := []struct {
+		label   string
+		limit   configRiskScoreLimit
+		wantIDs []string
+		passed  bool
+	}{
+		{label: "disabled", limit: configRiskScoreLimit{Score: 0, Weights: configRiskWeights{Severity: 1}}, passed: true},
+		{label: "default-weights", limit: configRiskScoreLimit{Enabled: true, Score: 5, Weights: configRiskWeights{Severity: 2, EPSS: 3, KEV: 3, CVSS: 2}}, wantIDs: []string{"cve-exploited"}},
+		{label: "severity-cvss", limit: configRiskScoreLimit{Enabled: true, Score: 5, Weights: configRiskWeights{Severity: 1, CVSS: 1}}, wantIDs: []string{"cve-critical", "cve-exploited"}},
+		{label: "under-limit", limit: configRiskScoreLimit{Enabled: true, Score: 10, Weights: configRiskWeights{Severity: 1}}, passed: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := new(Config)
+			config.Trivy.RiskScoreLimit = testCase.limit
+
+			result := ruleRiskScoreLimit(config.Trivy, trivyVulnerabilities(report), catalog, data)
+			if result.Passed() != testCase.passed {
+				t.Fatalf("want: passed %v got: %+v", testCase.passed, result)
+			}
+			gotIDs := make([]string, 0, len(result.Findings))
+			for _, finding := range result.Findings {
+				gotIDs = append(gotIDs, finding.ID)
+			}
+			if len(testCase.wantIDs) > 0 && !slices.Equal(testCase.wantIDs, gotIDs) {
+				t.Fatalf("want: %v got: %v", testCase.wantIDs, gotIDs)
+			}
+		})
+	}
+}
+
+func TestList_riskScore(t *testing.T) {
+	report, catalog, data := riskTestData()
+	src := new(bytes.Buffer)
+	_ = json.NewEncoder(src).Encode(report)
+
+	options := []ListOptionFunc{
+		WithRiskScore(nil),
+		func(o *listOptions) { o.epssData = data },
+		func(o *listOptions) { o.kevCatalog = catalog },
+	}
+	dst := new(bytes.Buffer)
+	if err := List(dst, src, "trivy-report.json", options...); err != nil {
+		t.Fatal(err)
+	}
+
+	// highest risk score first
+	want := []string{"cve-exploited", "cve-critical", "cve-low"}
+	got := []string{}
+	for _, line := range strings.Split(dst.String(), "\n") {
+		for _, id := range want {
+			if strings.Contains(line, id) {
+				got = append(got, id)
+			}
+		}
+	}
+	if !slices.Equal(want, got) {
+		t.Fatalf("want: %v got: %v\n%s", want, got, dst.String())
+	}
+}
+
+func TestList_cyclonedxUnrated(t *testing.T) {
+	// a vulnerability without ratings is listed with severity none
+	report := &artifacts.CyclonedxReportMin{Vulnerabilities: []artifacts.CyclonedxVulnerability{{ID: "cve-unrated"}}}
+	_, catalog, data := riskTestData()
+
+	testTable := []struct {
+		label   string
+		options []ListOptionFunc
+	}{
+		{label: "plain"},
+		{label: "epss", options: []ListOptionFunc{func(o *listOptions) { o.epssData = data }}},
+		{label: "risk-score", options: []ListOptionFunc{
+			WithRiskScore(nil),
+			func(o *listOptions) { o.epssData = data },
+			func(o *listOptions) { o.kevCatalog = catalog },
+		}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			src := new(bytes.Buffer)
+			_ = json.NewEncoder(src).Encode(report)
+			dst := new(bytes.Buffer)
+			if err := List(dst, src, "cyclonedx-report.json", testCase.options...); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(dst.String(), "cve-unrated") || !strings.Contains(dst.String(), "none") {
+				t.Fatalf("want: cve-unrated with severity none got:\n%s", dst.String())
+			}
+		})
+	}
+}
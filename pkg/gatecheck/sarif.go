@@ -0,0 +1,288 @@
+package gatecheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// acceptanceReasons the suppression justification for each risk acceptance rule
+var acceptanceReasons = map[string]string{
+	RuleCVEAllow:             "CVE allow list",
+	RuleRuleAllow:            "rule allow list",
+	RuleEPSSAllow:            "EPSS acceptance",
+	RuleImpactRiskAcceptance: "impact acceptance",
+	RuleLicense:              "license exception",
+	RuleBaseline:             "baseline finding",
+}
+
+// SARIF 2.1.0 data model, only the fields gatecheck writes
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func newSarifLog() *sarifLog {
+	return &sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: make([]sarifRun, 0)}
+}
+
+func newSarifRun(toolName string) sarifRun {
+	return sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: toolName}},
+		Results: make([]sarifResult, 0),
+	}
+}
+
+// sarifLevel maps report severities to a SARIF level
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high", "error":
+		return "error"
+	case "medium", "moderate", "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifFindingResult converts a finding into a SARIF result
+//
+// If the finding has no path, the artifact label is used as the location
+// since most consumers require at least one location
+func sarifFindingResult(finding Finding, level string, artifactLabel string) sarifResult {
+	text := finding.ID
+	if finding.Package != "" {
+		text = fmt.Sprintf("%s in %s", text, strings.TrimSpace(finding.Package+" "+finding.Version))
+	}
+	if finding.Severity != "" {
+		text = fmt.Sprintf("%s (%s)", text, finding.Severity)
+	}
+	if finding.Detail != "" {
+		text = fmt.Sprintf("%s: %s", text, finding.Detail)
+	}
+
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: artifactLabel}}}
+	if finding.Path != "" {
+		location.PhysicalLocation.ArtifactLocation.URI = finding.Path
+	}
+	if finding.Line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Line}
+	}
+
+	return sarifResult{
+		RuleID:    finding.ID,
+		Level:     level,
+		Message:   sarifMessage{Text: strings.TrimSpace(text)},
+		Locations: []sarifLocation{location},
+	}
+}
+
+func encodeSarif(w io.Writer, log *sarifLog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifFindingKey identifies a finding within an artifact
+//
+// The same finding can fail more than one rule, such as a severity and a CVSS limit
+func sarifFindingKey(finding Finding) string {
+	return fmt.Sprintf("%s|%s|%s", finding.ID, finding.Package, finding.Path)
+}
+
+// EncodeSarifTo writes the validation result as a SARIF 2.1.0 log
+//
+// Each artifact is a run with one result per finding, findings that failed a rule
+// are at the error level and list every failed rule in the result properties.
+// Risk accepted findings are marked as suppressed with the acceptance reason.
+// Findings that passed every rule are not written
+func EncodeSarifTo(w io.Writer, result *ValidationResult) error {
+	log := newSarifLog()
+
+	for _, artifact := range result.Artifacts {
+		run := newSarifRun(artifact.Type)
+		failed := make(map[string]int)
+		for _, rule := range artifact.Rules {
+			for _, finding := range rule.Findings {
+				key := sarifFindingKey(finding)
+				if idx, ok := failed[key]; ok {
+					properties := run.Results[idx].Properties
+					properties["gatecheckRules"] = append(properties["gatecheckRules"].([]string), rule.Rule)
+					properties["gatecheckMessages"] = append(properties["gatecheckMessages"].([]string), rule.Message)
+					continue
+				}
+				sarifResult := sarifFindingResult(finding, "error", artifact.Label)
+				sarifResult.Properties = map[string]any{
+					"gatecheckRules":    []string{rule.Rule},
+					"gatecheckMessages": []string{rule.Message},
+				}
+				failed[key] = len(run.Results)
+				run.Results = append(run.Results, sarifResult)
+			}
+		}
+
+		// a finding that failed a rule is not suppressed even if another rule accepted it
+		accepted := make(map[string]bool)
+		for _, rule := range artifact.Rules {
+			for _, finding := range rule.Accepted {
+				key := sarifFindingKey(finding)
+				if _, ok := failed[key]; ok || accepted[key] {
+					continue
+				}
+				accepted[key] = true
+				sarifResult := sarifFindingResult(finding, sarifLevel(finding.Severity), artifact.Label)
+				sarifResult.Properties = map[string]any{"gatecheckRules": []string{rule.Rule}}
+				justification := acceptanceReasons[rule.Rule]
+				if finding.Detail != "" {
+					justification = fmt.Sprintf("%s: %s", justification, finding.Detail)
+				}
+				sarifResult.Suppressions = []sarifSuppression{{Kind: "external", Justification: justification}}
+				run.Results = append(run.Results, sarifResult)
+			}
+		}
+		log.Runs = append(log.Runs, run)
+	}
+
+	return encodeSarif(w, log)
+}
+
+func listAsSarif(dst io.Writer, src io.Reader, artifactType string, inputFilename string) error {
+	var findings []Finding
+	var toolName string
+
+	switch artifactType {
+	case artifacts.TypeSarif:
+		// already a sarif log
+		_, err := io.Copy(dst, src)
+		return err
+
+	case artifacts.TypeGrype:
+		toolName = "grype"
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return err
+		}
+		for _, match := range report.Matches {
+			findings = append(findings, grypeFinding(match))
+		}
+
+	case artifacts.TypeCyclonedx:
+		toolName = "cyclonedx"
+		report := &artifacts.CyclonedxReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return err
+		}
+		for idx, vulnerability := range report.Vulnerabilities {
+			finding := cyclonedxFinding(vulnerability)
+			finding.Package = report.AffectedPackages(idx)
+			findings = append(findings, finding)
+		}
+
+	case artifacts.TypeTrivy:
+		toolName = "trivy"
+		report := &artifacts.TrivyReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return err
+		}
+		for _, vulnerability := range report.Vulnerabilities() {
+			findings = append(findings, trivyFinding(vulnerability))
+		}
+
+	case artifacts.TypeSemgrep:
+		toolName = "semgrep"
+		report := &artifacts.SemgrepReportMin{}
+		if err := json.NewDecoder(src).Decode(report); err != nil {
+			return err
+		}
+		for _, result := range report.Results {
+			findings = append(findings, semgrepFinding(result))
+		}
+
+	case artifacts.TypeGitleaks:
+		toolName = "gitleaks"
+		report := artifacts.GitLeaksReportMin{}
+		if err := json.NewDecoder(src).Decode(&report); err != nil {
+			return err
+		}
+		for _, finding := range report {
+			// every committed secret is treated as a high severity finding
+			f := gitleaksFinding(finding)
+			f.Severity = "high"
+			findings = append(findings, f)
+		}
+
+	default:
+		slog.Error("unsupported file type for sarif", "filename", inputFilename, "filetype", artifactType)
+		return errors.New("failed to list artifact content as sarif")
+	}
+
+	log := newSarifLog()
+	run := newSarifRun(toolName)
+	for _, finding := range findings {
+		run.Results = append(run.Results, sarifFindingResult(finding, sarifLevel(finding.Severity), inputFilename))
+	}
+	log.Runs = append(log.Runs, run)
+
+	return encodeSarif(dst, log)
+}
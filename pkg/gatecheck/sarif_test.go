@@ -0,0 +1,84 @@
+package gatecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncodeSarifTo(t *testing.T) {
+	cveAllow := newRuleResult(RuleCVEAllow, "grype").accept(Finding{ID: "cve-1", Severity: "High"})
+	result := &ValidationResult{Artifacts: []*ArtifactResult{
+		{
+			Label: "grype-report.json",
+			Type:  "grype",
+			Rules: []RuleResult{
+				cveAllow,
+				// set aside without a fix is not risk accepted, so it is not a suppressed result
+				newRuleResult(RuleFixAvailable, "grype").setAside(Finding{ID: "cve-3", Severity: "Critical"}),
+				newRuleResult(RuleSeverityLimit, "grype").fail("Severity Limit Exceeded", Finding{ID: "cve-2", Severity: "Low", Package: "pkg"}),
+				newRuleResult(RuleCVSSLimit, "grype").fail("CVSS Limit Exceeded", Finding{ID: "cve-2", Severity: "Low", Package: "pkg"}),
+			},
+		},
+	}}
+
+	buf := new(bytes.Buffer)
+	if err := EncodeSarifTo(buf, result); err != nil {
+		t.Fatal(err)
+	}
+
+	log := new(sarifLog)
+	if err := json.NewDecoder(buf).Decode(log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 || len(log.Runs[0].Results) != 2 {
+		t.Fatalf("want: 1 run with 2 results got: %+v", log)
+	}
+
+	for _, sarifResult := range log.Runs[0].Results {
+		switch sarifResult.RuleID {
+		case "cve-1":
+			if len(sarifResult.Suppressions) != 1 || sarifResult.Suppressions[0].Justification != "CVE allow list" {
+				t.Fatalf("want: suppressed by CVE allow list got: %+v", sarifResult.Suppressions)
+			}
+		case "cve-2":
+			if sarifResult.Level != "error" || len(sarifResult.Suppressions) != 0 {
+				t.Fatalf("want: unsuppressed error got: %+v", sarifResult)
+			}
+			rules, _ := sarifResult.Properties["gatecheckRules"].([]any)
+			if len(rules) != 2 || rules[0] != RuleSeverityLimit || rules[1] != RuleCVSSLimit {
+				t.Fatalf("want: both failed rules got: %+v", sarifResult.Properties)
+			}
+		}
+	}
+}
+
+func Test_listAsSarif(t *testing.T) {
+	testTable := []struct {
+		filename string
+		label    string
+	}{
+		{filename: "grype-report.json", label: "grype-report.json"},
+		{filename: "cyclonedx-grype-sbom.json", label: "cyclonedx-grype-sbom.json"},
+		{filename: "trivy-report.json", label: "trivy-report.json"},
+		{filename: "semgrep-sast-report.json", label: "semgrep-sast-report.json"},
+		{filename: "gitleaks-report.json", label: "gitleaks-report.json"},
+		{filename: "sarif-report.sarif", label: "sarif-report.sarif"},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			src := MustOpen("../../test/"+testCase.filename, t)
+			if err := List(buf, src, testCase.label, WithDisplayFormat("sarif")); err != nil {
+				t.Fatal(err)
+			}
+			log := new(sarifLog)
+			if err := json.NewDecoder(buf).Decode(log); err != nil {
+				t.Fatal(err)
+			}
+			if len(log.Runs) == 0 || len(log.Runs[0].Results) == 0 {
+				t.Fatalf("want: run with results got: %+v", log.Runs)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package gatecheck
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
 	"slices"
 	"sort"
 	"strings"
@@ -26,53 +28,103 @@ func newValidationErr(details string) error {
 }
 
 // Validate against config thresholds
-func Validate(config *Config, reportSrc io.Reader, targetFilename string, optionFuncs ...optionFunc) error {
+//
+// The returned result contains the outcome of each evaluated rule.
+// The error is derived from the result for validation failures or
+// describes why the artifact could not be validated
+func Validate(config *Config, reportSrc io.Reader, targetFilename string, optionFuncs ...optionFunc) (*ValidationResult, error) {
 	options := defaultOptions()
 	for _, f := range optionFuncs {
 		f(options)
 	}
 
-	switch {
-	case strings.Contains(targetFilename, "grype"):
+	result := &ValidationResult{Artifacts: make([]*ArtifactResult, 0)}
+
+	if options.baselineFile != nil {
+		slog.Debug("load baseline", "filename", options.baselineFile.Name())
+		base, err := loadBaseline(options.baselineFile, options.baselineFile.Name())
+		if err != nil {
+			return result, err
+		}
+		options.baseline = base
+	}
+
+	artifactType, reportSrc, err := detectArtifactType(targetFilename, reportSrc)
+	if err != nil {
+		slog.Error("unsupported file type, cannot be determined from filename or content", "filename", targetFilename, "error", err)
+		return result, errors.New("failed to validate artifact, See log for details")
+	}
+
+	if options.requireSignature && artifactType != artifacts.TypeBundle {
+		slog.Error("signature required, only gatecheck bundles can be signed", "filename", targetFilename, "filetype", artifactType)
+		return result, errors.New("failed to validate artifact: signature required, See log for details")
+	}
+
+	var artifactResult *ArtifactResult
+
+	switch artifactType {
+	case artifacts.TypeSarif:
+		slog.Debug("validate", "filename", targetFilename, "filetype", "sarif")
+		artifactResult, err = validateSarifReport(reportSrc, config, options.failFast)
+
+	case artifacts.TypeGrype:
 		slog.Debug("validate grype report", "filename", targetFilename)
-		return validateGrypeReportWithFetch(reportSrc, config, options)
+		artifactResult, err = validateGrypeReportWithFetch(reportSrc, config, options)
 
-	case strings.Contains(targetFilename, "cyclonedx"):
+	case artifacts.TypeCyclonedx:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "cyclonedx")
-		return validateCyclonedxReportWithFetch(reportSrc, config, options)
+		artifactResult, err = validateCyclonedxReportWithFetch(reportSrc, config, options)
+
+	case artifacts.TypeTrivy:
+		slog.Debug("validate", "filename", targetFilename, "filetype", "trivy")
+		artifactResult, err = validateTrivyReportWithFetch(reportSrc, config, options)
 
-	case strings.Contains(targetFilename, "semgrep"):
+	case artifacts.TypeSemgrep:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "semgrep")
-		return validateSemgrepReport(reportSrc, config)
+		artifactResult, err = validateSemgrepReport(reportSrc, config, options.baseline, options.failFast)
 
-	case strings.Contains(targetFilename, "gitleaks"):
+	case artifacts.TypeGitleaks:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "gitleaks")
-		return validateGitleaksReport(reportSrc, config)
+		artifactResult, err = validateGitleaksReport(reportSrc, config, options.baseline, options.failFast)
 
-	case strings.Contains(targetFilename, "syft"):
+	case artifacts.TypeSyft:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "syft")
-		return errors.New("syft validation not supported yet")
+		artifactResult, err = validateSyftReport(reportSrc, config, options.failFast)
 
-	case strings.Contains(targetFilename, "bundle"):
+	case artifacts.TypeBundle:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "bundle")
 		return validateBundle(reportSrc, config, options)
 
-	case artifacts.IsCoverageReport(targetFilename):
+	case artifacts.TypeLCOV, artifacts.TypeCobertura, artifacts.TypeClover:
 		slog.Debug("validate", "filename", targetFilename, "filetype", "coverage")
-		return validateCoverage(reportSrc, targetFilename, config)
+		artifactResult, err = validateCoverage(reportSrc, coverage.CoverageMode(artifactType), config, options.failFast)
 
 	default:
-		slog.Error("unsupported file type, cannot be determined from filename", "filename", targetFilename)
-		return errors.New("failed to validate artifact, See log for details")
+		slog.Error("unsupported file type", "filename", targetFilename, "filetype", artifactType)
+		return result, errors.New("failed to validate artifact, See log for details")
 	}
+
+	if err != nil {
+		return result, err
+	}
+
+	artifactResult.Label = targetFilename
+	result.Artifacts = append(result.Artifacts, artifactResult)
+
+	return result, result.Err()
 }
 
-func removeIgnoredSeverityCVEs(config *Config, report *artifacts.GrypeReportMin, data *epss.Data) {
+func removeIgnoredSeverityCVEs(config reportWithCVEs, report *vulnerabilityReport, catalog *kev.Catalog, data *epss.Data) {
+	// vulnerabilities without a fix can have separate limits
+	notFixed := config.FixAvailability.NotFixedSeverityLimit
+	if !config.FixAvailability.FixableOnly {
+		notFixed = configServerityLimit{}
+	}
 	hasLimits := map[string]bool{
-		"critical":   config.Grype.SeverityLimit.Critical.Enabled,
-		"high":       config.Grype.SeverityLimit.High.Enabled,
-		"medium":     config.Grype.SeverityLimit.Medium.Enabled,
-		"low":        config.Grype.SeverityLimit.Low.Enabled,
+		"critical":   config.SeverityLimit.Critical.Enabled || notFixed.Critical.Enabled,
+		"high":       config.SeverityLimit.High.Enabled || notFixed.High.Enabled,
+		"medium":     config.SeverityLimit.Medium.Enabled || notFixed.Medium.Enabled,
+		"low":        config.SeverityLimit.Low.Enabled || notFixed.Low.Enabled,
 		"unknown":    false,
 		"negligible": false,
 	}
@@ -82,386 +134,460 @@ func removeIgnoredSeverityCVEs(config *Config, report *artifacts.GrypeReportMin,
 			continue
 		}
 
-		if config.Grype.EPSSLimit.Enabled {
-			report.Matches = slices.DeleteFunc(report.Matches, func(match artifacts.GrypeMatch) bool {
-				epssCVE, ok := data.CVEs[match.Vulnerability.ID]
-				return strings.ToLower(match.Vulnerability.Severity) == severity && (!ok || epssCVE.EPSSValue() < config.Grype.EPSSLimit.Score)
-			})
-		} else {
-			report.Matches = slices.DeleteFunc(report.Matches, func(match artifacts.GrypeMatch) bool {
-				return strings.ToLower(match.Vulnerability.Severity) == severity
-			})
-		}
+		// keep vulnerabilities that can still fail the EPSS, CVSS or risk score limits
+		report.Vulnerabilities = slices.DeleteFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
+			if vulnerability.Severity != severity {
+				return false
+			}
+			if config.EPSSLimit.Enabled && data != nil {
+				if epssCVE, ok := data.CVEs[vulnerability.ID]; ok && config.EPSSLimit.exceeded(epssCVE) {
+					return false
+				}
+			}
+			if config.CVSSLimit.Enabled {
+				if _, exceeded := cvssLimitExceeded(config.CVSSLimit, vulnerability.CVSSScores); exceeded {
+					return false
+				}
+			}
+			if limit := config.RiskScoreLimit; limit.Enabled {
+				factors := newRiskFactors(vulnerability.ID, vulnerability.Severity, vulnerability.CVSSScores, catalog, data)
+				if factors.score(limit.Weights) > limit.Score {
+					return false
+				}
+			}
+			return true
+		})
 	}
 }
 
-func ruleGrypeSeverityLimit(config *Config, report *artifacts.GrypeReportMin) bool {
-	validationPass := true
+func grypeFinding(match artifacts.GrypeMatch) Finding {
+	finding := Finding{
+		ID:       match.Vulnerability.ID,
+		Severity: match.Vulnerability.Severity,
+		Package:  match.Artifact.Name,
+		Version:  match.Artifact.Version,
+		FixedIn:  match.Vulnerability.FixedIn(),
+	}
+	if len(match.Artifact.Locations) > 0 {
+		finding.Path = match.Artifact.Locations[0].Path
+	}
+	return finding
+}
 
-	limits := map[string]configLimit{
-		"critical": config.Grype.SeverityLimit.Critical,
-		"high":     config.Grype.SeverityLimit.High,
-		"medium":   config.Grype.SeverityLimit.Medium,
-		"low":      config.Grype.SeverityLimit.Low,
+func cyclonedxFinding(vulnerability artifacts.CyclonedxVulnerability) Finding {
+	finding := Finding{ID: vulnerability.ID, FixedIn: vulnerability.FixedIn()}
+	if len(vulnerability.Ratings) > 0 {
+		finding.Severity = vulnerability.HighestSeverity()
 	}
+	return finding
+}
 
-	for _, severity := range []string{"critical", "high", "medium", "low"} {
+func trivyFinding(vulnerability artifacts.TrivyVulnerability) Finding {
+	return Finding{
+		ID:       vulnerability.VulnerabilityID,
+		Severity: strings.ToLower(vulnerability.Severity),
+		Package:  vulnerability.PkgName,
+		Version:  vulnerability.InstalledVersion,
+		FixedIn:  vulnerability.FixedVersion,
+	}
+}
 
-		configuredLimit := limits[severity]
-		matches := report.SelectBySeverity(severity)
-		matchCount := len(matches)
-		if !configuredLimit.Enabled {
-			slog.Debug("severity limit not enabled", "artifact", "grype", "severity", severity, "reported", matchCount)
-			continue
-		}
-		if matchCount > int(configuredLimit.Limit) {
-			slog.Error("grype severity limit exceeded", "severity", severity, "report", matchCount, "limit", configuredLimit.Limit)
-			for _, match := range matches {
-				slog.Info("vulnerability detected", "id", match.Vulnerability.ID, "severity", match.Vulnerability.Severity)
-			}
-			validationPass = false
-			continue
+// severityThresholds the enabled limits by severity for rule results
+func severityThresholds(limits map[string]configLimit) map[string]any {
+	thresholds := make(map[string]any)
+	for severity, limit := range limits {
+		if limit.Enabled {
+			thresholds[severity] = limit.Limit
 		}
-		slog.Info("severity limit valid", "artifact", "grype", "severity", severity, "reported", matchCount, "limit", configuredLimit.Limit)
 	}
-
-	return validationPass
+	return thresholds
 }
 
-func ruleCyclonedxSeverityLimit(config *Config, report *artifacts.CyclonedxReportMin) bool {
-	validationPass := true
+func ruleSeverityLimit(config reportWithCVEs, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleSeverityLimit, report.Artifact)
 
 	limits := map[string]configLimit{
-		"critical": config.Cyclonedx.SeverityLimit.Critical,
-		"high":     config.Cyclonedx.SeverityLimit.High,
-		"medium":   config.Cyclonedx.SeverityLimit.Medium,
-		"low":      config.Cyclonedx.SeverityLimit.Low,
+		"critical": config.SeverityLimit.Critical,
+		"high":     config.SeverityLimit.High,
+		"medium":   config.SeverityLimit.Medium,
+		"low":      config.SeverityLimit.Low,
+	}
+	result.Thresholds = severityThresholds(limits)
+	if len(result.Thresholds) == 0 {
+		result = result.skip()
 	}
 
 	for _, severity := range []string{"critical", "high", "medium", "low"} {
 
 		configuredLimit := limits[severity]
-		vulnerabilities := report.SelectBySeverity(severity)
+		vulnerabilities := report.selectBySeverity(severity)
 		matchCount := len(vulnerabilities)
 		if !configuredLimit.Enabled {
-			slog.Debug("severity limit not enabled", "artifact", "cyclonedx", "severity", severity, "reported", matchCount)
+			slog.Debug("severity limit not enabled", "artifact", report.Artifact, "severity", severity, "reported", matchCount)
 			continue
 		}
 		if matchCount > int(configuredLimit.Limit) {
-			slog.Error("severity limit exceeded", "artifact", "cyclonedx", "severity", severity, "report", matchCount, "limit", configuredLimit.Limit)
-			validationPass = false
+			slog.Error("severity limit exceeded", "artifact", report.Artifact, "severity", severity, "report", matchCount, "limit", configuredLimit.Limit)
+			findings := make([]Finding, 0, matchCount)
+			for _, vulnerability := range vulnerabilities {
+				slog.Info("vulnerability detected", "artifact", report.Artifact, "id", vulnerability.ID, "severity", vulnerability.Severity)
+				findings = append(findings, vulnerability.Finding)
+			}
+			result = result.fail("Severity Limit Exceeded", findings...)
 			continue
 		}
-		slog.Info("severity limit valid", "artifact", "cyclonedx", "severity", severity, "reported", matchCount, "limit", configuredLimit.Limit)
+		slog.Info("severity limit valid", "artifact", report.Artifact, "severity", severity, "reported", matchCount, "limit", configuredLimit.Limit)
 	}
 
-	return validationPass
+	return result
 }
 
-func ruleGrypeCVEDeny(config *Config, report *artifacts.GrypeReportMin) bool {
-	if !config.Grype.CVELimit.Enabled {
-		slog.Debug("cve id limits not enabled", "artifact", "grype", "count_denied", len(config.Grype.CVELimit.CVEs))
-		return true
-	}
-	for _, cve := range config.Grype.CVELimit.CVEs {
-		contains := slices.ContainsFunc(report.Matches, func(match artifacts.GrypeMatch) bool {
-			return strings.EqualFold(match.Vulnerability.ID, cve.ID)
+func ruleCVEDeny(config reportWithCVEs, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleCVEDeny, report.Artifact)
+	if !config.CVELimit.Enabled {
+		slog.Debug("cve id limits not enabled", "artifact", report.Artifact, "count_denied", len(config.CVELimit.CVEs))
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{"denied": len(config.CVELimit.CVEs)}
+	deniedCVEs := make([]Finding, 0)
+	for _, cve := range config.CVELimit.CVEs {
+		idx := slices.IndexFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
+			return vulnerability.matchedBy(cve)
 		})
 
-		if contains {
-			slog.Error("cve matched to Deny List", "artifact", "grype", "id", cve.ID, "metadata", fmt.Sprintf("%+v", cve))
-			return false
+		if idx != -1 {
+			slog.Error("cve matched to Deny List", "artifact", report.Artifact, "id", cve.ID, "metadata", fmt.Sprintf("%+v", cve))
+			deniedCVEs = append(deniedCVEs, report.Vulnerabilities[idx].Finding)
 		}
 	}
-	return true
-}
-
-func ruleCyclonedxCVEDeny(config *Config, report *artifacts.CyclonedxReportMin) bool {
-	if !config.Cyclonedx.CVELimit.Enabled {
-		slog.Debug("cve id limits not enabled", "artifact", "cyclonedx", "count_denied", len(config.Cyclonedx.CVELimit.CVEs))
-		return true
-	}
-	for _, cve := range config.Cyclonedx.CVELimit.CVEs {
-		contains := slices.ContainsFunc(report.Vulnerabilities, func(vulerability artifacts.CyclonedxVulnerability) bool {
-			return strings.EqualFold(vulerability.ID, cve.ID)
-		})
-
-		if contains {
-			slog.Error("cve matched to Deny List", "artifact", "cyclonedx", "id", cve.ID, "metadata", fmt.Sprintf("%+v", cve))
-			return false
-		}
+	if len(deniedCVEs) > 0 {
+		return result.fail("CVE explicitly denied", deniedCVEs...)
 	}
-	return true
+	return result
 }
 
-func ruleGrypeCVEAllow(config *Config, report *artifacts.GrypeReportMin) {
-	slog.Debug("cve id risk acceptance rule", "artifact", "grype",
-		"enabled", config.Grype.CVERiskAcceptance.Enabled,
-		"risk_accepted_cves", len(config.Grype.CVERiskAcceptance.CVEs),
+func ruleCVEAllow(config reportWithCVEs, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleCVEAllow, report.Artifact)
+	slog.Debug("cve id risk acceptance rule", "artifact", report.Artifact,
+		"enabled", config.CVERiskAcceptance.Enabled,
+		"risk_accepted_cves", len(config.CVERiskAcceptance.CVEs),
 	)
 
-	if !config.Grype.CVERiskAcceptance.Enabled {
-		return
+	if !config.CVERiskAcceptance.Enabled {
+		return result.skip()
 	}
-	matches := slices.DeleteFunc(report.Matches, func(match artifacts.GrypeMatch) bool {
-		allowed := slices.ContainsFunc(config.Grype.CVERiskAcceptance.CVEs, func(cve configCVE) bool {
-			return strings.EqualFold(cve.ID, match.Vulnerability.ID)
-		})
-		if allowed {
-			slog.Info("CVE explicitly allowed, removing from subsequent rules",
-				"id", match.Vulnerability.ID, "severity", match.Vulnerability.Severity)
+	result.Thresholds = map[string]any{"accepted": len(config.CVERiskAcceptance.CVEs)}
+	acceptedCVEs, warnings := activeCVEAcceptances(config.CVERiskAcceptance, report.Artifact)
+	result = result.warn(warnings...)
+	report.Vulnerabilities = slices.DeleteFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
+		idx := slices.IndexFunc(acceptedCVEs, vulnerability.coveredBy)
+		if idx == -1 {
+			return false
 		}
-		return allowed
+		slog.Info("CVE explicitly allowed, removing from subsequent rules", "artifact", report.Artifact,
+			"id", vulnerability.ID, "severity", vulnerability.Severity, "owner", acceptedCVEs[idx].Owner)
+		result = result.accept(vulnerability.findingWithDetail(acceptanceDetail(acceptedCVEs[idx])))
+		return true
 	})
 
-	report.Matches = matches
+	return result
 }
 
-func ruleCyclonedxCVEAllow(config *Config, report *artifacts.CyclonedxReportMin) {
-	slog.Debug(
-		"cve id risk acceptance rule", "artifact", "cyclonedx",
-		"enabled", config.Cyclonedx.CVERiskAcceptance.Enabled,
-		"risk_accepted_cves", len(config.Cyclonedx.CVERiskAcceptance.CVEs),
-	)
+// KEV Limit
+//
+// Any vulnerability in the catalog fails unless the limit is narrowed by the catalog metadata
 
-	if !config.Cyclonedx.CVERiskAcceptance.Enabled {
-		return
+// applies true if the catalog entry fails the KEV limit
+func (limit configKEVLimit) applies(entry kev.Vulnerability) bool {
+	if limit.PastDueOnly && !entry.PastDue(timeNow()) {
+		return false
+	}
+	if limit.RansomwareOnly && !entry.KnownRansomwareUse() {
+		return false
 	}
+	return true
+}
 
-	vulnerabilities := slices.DeleteFunc(report.Vulnerabilities, func(vulnerability artifacts.CyclonedxVulnerability) bool {
-		allowed := slices.ContainsFunc(config.Cyclonedx.CVERiskAcceptance.CVEs, func(cve configCVE) bool {
-			return strings.EqualFold(cve.ID, vulnerability.ID)
-		})
-		if allowed {
-			slog.Info("CVE explicitly allowed, removing from subsequent rules",
-				"id", vulnerability.ID, "severity", vulnerability.HighestSeverity())
-		}
-		return allowed
-	})
+func (limit configKEVLimit) thresholds() map[string]any {
+	return map[string]any{"pastDueOnly": limit.PastDueOnly, "ransomwareOnly": limit.RansomwareOnly}
+}
 
-	report.Vulnerabilities = vulnerabilities
+func kevDetail(entry kev.Vulnerability) string {
+	details := []string{"due " + entry.DueDate}
+	if entry.KnownRansomwareUse() {
+		details = append(details, "known ransomware campaign use")
+	}
+	return strings.Join(details, ", ")
 }
 
-func ruleGrypeKEVLimit(config *Config, report *artifacts.GrypeReportMin, catalog *kev.Catalog) bool {
-	if !config.Grype.KEVLimitEnabled {
-		slog.Debug("kev limit not enabled", "artifact", "grype")
-		return true
+func ruleKEVLimit(config reportWithCVEs, report *vulnerabilityReport, catalog *kev.Catalog) RuleResult {
+	result := newRuleResult(RuleKEVLimit, report.Artifact)
+	if !config.KEVLimitEnabled {
+		slog.Debug("kev limit not enabled", "artifact", report.Artifact)
+		return result.skip()
 	}
 	if catalog == nil {
-		slog.Error("kev limit enabled but no catalog data exists")
-		return false
+		slog.Error("kev limit enabled but no catalog data exists", "artifact", report.Artifact)
+		return result.fail("KEV limit enabled but no catalog data exists")
 	}
-	badCVEs := make([]string, 0)
+	result.Thresholds = config.KEVLimit.thresholds()
+	badCVEs := make([]Finding, 0)
 	// Check if vulnerability is in the KEV Catalog
-	for _, vulnerability := range report.Matches {
-		inKEVCatalog := slices.ContainsFunc(catalog.Vulnerabilities, func(kevVul kev.Vulnerability) bool {
-			return kevVul.CveID == vulnerability.Vulnerability.ID
-		})
-		if inKEVCatalog {
-			badCVEs = append(badCVEs, vulnerability.Vulnerability.ID)
-			slog.Warn("cve found in kev catalog",
-				"cve_id", vulnerability.Vulnerability.ID)
+	for _, vulnerability := range report.Vulnerabilities {
+		entry, inKEVCatalog := catalog.Lookup(vulnerability.ID)
+		if !inKEVCatalog {
+			continue
+		}
+		if !config.KEVLimit.applies(entry) {
+			slog.Info("cve found in kev catalog, not applicable to the kev limit", "artifact", report.Artifact,
+				"cve_id", vulnerability.ID, "due_date", entry.DueDate, "known_ransomware_campaign_use", entry.KnownRansomwareCampaignUse)
+			result = result.warn(fmt.Sprintf("%s in KEV catalog, %s", vulnerability.ID, kevDetail(entry)))
+			continue
 		}
+		badCVEs = append(badCVEs, vulnerability.findingWithDetail(kevDetail(entry)))
+		slog.Warn("cve found in kev catalog", "artifact", report.Artifact,
+			"cve_id", vulnerability.ID, "due_date", entry.DueDate, "known_ransomware_campaign_use", entry.KnownRansomwareCampaignUse)
 	}
 	if len(badCVEs) > 0 {
-		slog.Error("cve(s) found in kev catalog",
+		slog.Error("cve(s) found in kev catalog", "artifact", report.Artifact,
 			"vulnerabilities", len(badCVEs), "kev_catalog_count", len(catalog.Vulnerabilities))
-		return false
+		return result.fail("CVE matched to KEV Catalog", badCVEs...)
 	}
-	slog.Info("kev limit validated, no cves in catalog",
-		"vulnerabilities", len(report.Matches), "kev_catalog_count", len(catalog.Vulnerabilities))
-	return true
+	slog.Info("kev limit validated, no cves in catalog", "artifact", report.Artifact,
+		"vulnerabilities", len(report.Vulnerabilities), "kev_catalog_count", len(catalog.Vulnerabilities))
+	return result
 }
 
-func ruleCyclonedxKEVLimit(config *Config, report *artifacts.CyclonedxReportMin, catalog *kev.Catalog) bool {
-	if !config.Cyclonedx.KEVLimitEnabled {
-		slog.Debug("kev limit not enabled", "artifact", "cyclonedx")
-		return true
-	}
-	if catalog == nil {
-		slog.Error("kev limit enabled but no catalog data exists", "artifact", "cyclonedx")
-		return false
-	}
-	badCVEs := make([]string, 0)
-	// Check if vulnerability is in the KEV Catalog
-	for _, vulnerability := range report.Vulnerabilities {
-		inKEVCatalog := slices.ContainsFunc(catalog.Vulnerabilities, func(kevVul kev.Vulnerability) bool {
-			return strings.EqualFold(kevVul.CveID, vulnerability.ID)
-		})
+// EPSS thresholds
+//
+// The score is the probability of exploitation, the percentile ranks the score against every other CVE.
+// A configured percentile replaces the score threshold
 
-		if inKEVCatalog {
-			badCVEs = append(badCVEs, vulnerability.ID)
-			slog.Warn("cve found in kev catalog",
-				"cve_id", vulnerability.ID)
-		}
+// exceeded true if the EPSS score or percentile is above the limit
+func (limit configEPSSLimit) exceeded(epssCVE epss.CVE) bool {
+	if limit.Percentile > 0 {
+		return epssCVE.PercentileValue() > limit.Percentile
 	}
-	if len(badCVEs) > 0 {
-		slog.Error("cve(s) found in kev catalog",
-			"vulnerabilities", len(badCVEs), "kev_catalog_count", len(catalog.Vulnerabilities))
-		return false
+	return epssCVE.EPSSValue() > limit.Score
+}
+
+func (limit configEPSSLimit) thresholds() map[string]any {
+	if limit.Percentile > 0 {
+		return map[string]any{"percentile": limit.Percentile}
 	}
-	slog.Info("kev limit validated, no cves in catalog",
-		"vulnerabilities", len(report.Vulnerabilities), "kev_catalog_count", len(catalog.Vulnerabilities))
-	return true
+	return map[string]any{"score": limit.Score}
 }
 
-func ruleGrypeEPSSAllow(config *Config, report *artifacts.GrypeReportMin, data *epss.Data) {
-	if !config.Grype.EPSSRiskAcceptance.Enabled {
-		slog.Debug("epss risk acceptance not enabled", "artifact", "grype")
-		return
+// accepted true if the EPSS score or percentile is below the threshold
+func (acceptance configEPSSRiskAcceptance) accepted(epssCVE epss.CVE) bool {
+	if acceptance.Percentile > 0 {
+		return acceptance.Percentile > epssCVE.PercentileValue()
 	}
-	if data == nil {
-		slog.Error("epss allowance enabled but no data exists")
-		return
+	return acceptance.Score > epssCVE.EPSSValue()
+}
+
+func (acceptance configEPSSRiskAcceptance) thresholds() map[string]any {
+	if acceptance.Percentile > 0 {
+		return map[string]any{"percentile": acceptance.Percentile}
 	}
-	slog.Debug("run epss risk acceptance filter",
-		"artifact", "grype",
-		"vulnerabilities", len(report.Matches),
-		"epss_risk_acceptance_score", config.Cyclonedx.EPSSRiskAcceptance.Score,
-	)
-	matches := slices.DeleteFunc(report.Matches, func(match artifacts.GrypeMatch) bool {
-		epssCVE, ok := data.CVEs[match.Vulnerability.ID]
-		if !ok {
-			slog.Debug("no epss score", "cve_id", match.Vulnerability.ID, "severity", match.Vulnerability.Severity)
-			return false
-		}
-		riskAccepted := config.Grype.EPSSRiskAcceptance.Score > epssCVE.EPSSValue()
-		if riskAccepted {
-			slog.Info(
-				"risk accepted reason: epss score",
-				"cve_id", match.Vulnerability.ID,
-				"severity", match.Vulnerability.Severity,
-				"epss_score", epssCVE.EPSS,
-			)
-			return true
-		}
-		return false
-	})
+	return map[string]any{"score": acceptance.Score}
+}
 
-	report.Matches = matches
+func epssDetail(epssCVE epss.CVE) string {
+	return fmt.Sprintf("epss score %s percentile %s", epssCVE.EPSS, epssCVE.Percentile)
 }
 
-func ruleCyclonedxEPSSAllow(config *Config, report *artifacts.CyclonedxReportMin, data *epss.Data) {
-	if !config.Cyclonedx.EPSSRiskAcceptance.Enabled {
-		slog.Debug("epss risk acceptance not enabled", "artifact", "cyclonedx")
-		return
+func ruleEPSSAllow(config reportWithCVEs, report *vulnerabilityReport, data *epss.Data) RuleResult {
+	result := newRuleResult(RuleEPSSAllow, report.Artifact)
+	if !config.EPSSRiskAcceptance.Enabled {
+		slog.Debug("epss risk acceptance not enabled", "artifact", report.Artifact)
+		return result.skip()
 	}
+	result.Thresholds = config.EPSSRiskAcceptance.thresholds()
 	if data == nil {
-		slog.Error("epss allowance enabled but no data exists", "artifact", "cyclonedx")
-		return
+		slog.Error("epss allowance enabled but no data exists", "artifact", report.Artifact)
+		return result.skip()
 	}
 	slog.Debug("run epss risk acceptance filter",
-		"artifact", "cyclonedx",
+		"artifact", report.Artifact,
 		"vulnerabilities", len(report.Vulnerabilities),
-		"epss_risk_acceptance_score", config.Cyclonedx.EPSSRiskAcceptance.Score,
+		"epss_risk_acceptance_score", config.EPSSRiskAcceptance.Score,
+		"epss_risk_acceptance_percentile", config.EPSSRiskAcceptance.Percentile,
 	)
-	vulnerabilities := slices.DeleteFunc(report.Vulnerabilities, func(vulnerability artifacts.CyclonedxVulnerability) bool {
+	report.Vulnerabilities = slices.DeleteFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
 		epssCVE, ok := data.CVEs[vulnerability.ID]
 		if !ok {
-			slog.Debug("no epss score", "cve_id", vulnerability.ID, "severity", vulnerability.HighestSeverity())
+			slog.Debug("no epss score", "artifact", report.Artifact, "cve_id", vulnerability.ID, "severity", vulnerability.Severity)
 			return false
 		}
-		riskAccepted := config.Cyclonedx.EPSSRiskAcceptance.Score > epssCVE.EPSSValue()
+		riskAccepted := config.EPSSRiskAcceptance.accepted(epssCVE)
 		if riskAccepted {
 			slog.Info(
 				"risk accepted reason: epss score",
+				"artifact", report.Artifact,
 				"cve_id", vulnerability.ID,
-				"severity", vulnerability.HighestSeverity(),
+				"severity", vulnerability.Severity,
 				"epss_score", epssCVE.EPSS,
+				"epss_percentile", epssCVE.Percentile,
 			)
+			result = result.accept(vulnerability.findingWithDetail(epssDetail(epssCVE)))
 			return true
 		}
 		return false
 	})
 
-	report.Vulnerabilities = vulnerabilities
+	return result
 }
 
-func ruleGrypeEPSSLimit(config *Config, report *artifacts.GrypeReportMin, data *epss.Data) bool {
-	if !config.Grype.EPSSLimit.Enabled {
-		slog.Debug("epss limit not enabled", "artifact", "grype")
-		return true
+func ruleEPSSLimit(config reportWithCVEs, report *vulnerabilityReport, data *epss.Data) RuleResult {
+	result := newRuleResult(RuleEPSSLimit, report.Artifact)
+	if !config.EPSSLimit.Enabled {
+		slog.Debug("epss limit not enabled", "artifact", report.Artifact)
+		return result.skip()
 	}
+	result.Thresholds = config.EPSSLimit.thresholds()
 	if data == nil {
-		slog.Error("epss allowance enabled but no data exists")
-		return false
+		slog.Error("epss limit enabled but no data exists", "artifact", report.Artifact)
+		return result.fail("EPSS limit enabled but no data exists")
 	}
 
-	badCVEs := make([]epss.CVE, 0)
+	badCVEs := make([]Finding, 0)
 
 	slog.Debug("run epss limit rule",
-		"artifact", "grype",
-		"vulnerabilities", len(report.Matches),
-		"epss_limit_score", config.Grype.EPSSLimit.Score,
+		"artifact", report.Artifact,
+		"vulnerabilities", len(report.Vulnerabilities),
+		"epss_limit_score", config.EPSSLimit.Score,
+		"epss_limit_percentile", config.EPSSLimit.Percentile,
 	)
-	for _, match := range report.Matches {
-		epssCVE, ok := data.CVEs[match.Vulnerability.ID]
+	for _, vulnerability := range report.Vulnerabilities {
+		epssCVE, ok := data.CVEs[vulnerability.ID]
 		if !ok {
 			continue
 		}
-		// add to badCVEs if the score is higher than the limit
-		if epssCVE.EPSSValue() > config.Grype.EPSSLimit.Score {
-			badCVEs = append(badCVEs, epssCVE)
+		// add to badCVEs if the score or percentile is higher than the limit
+		if config.EPSSLimit.exceeded(epssCVE) {
+			badCVEs = append(badCVEs, vulnerability.findingWithDetail(epssDetail(epssCVE)))
 			slog.Warn(
 				"epss score limit violation",
-				"cve_id", match.Vulnerability.ID,
-				"severity", match.Vulnerability.Severity,
+				"artifact", report.Artifact,
+				"cve_id", vulnerability.ID,
+				"severity", vulnerability.Severity,
 				"epss_score", epssCVE.EPSS,
+				"epss_percentile", epssCVE.Percentile,
 			)
 		}
 	}
 	if len(badCVEs) > 0 {
 		slog.Error("cve(s) with epss scores over limit",
+			"artifact", report.Artifact,
 			"over_limit_cves", len(badCVEs),
-			"epss_limit_score", config.Grype.EPSSLimit.Score,
+			"epss_limit_score", config.EPSSLimit.Score,
+			"epss_limit_percentile", config.EPSSLimit.Percentile,
 		)
-		return false
+		return result.fail("EPSS Limit Exceeded", badCVEs...)
 	}
-	return true
+	return result
 }
 
-func ruleCyclonedxEPSSLimit(config *Config, report *artifacts.CyclonedxReportMin, data *epss.Data) bool {
-	if !config.Cyclonedx.EPSSLimit.Enabled {
-		slog.Debug("epss limit not enabled", "artifact", "cyclonedx")
-		return true
-	}
-	if data == nil {
-		slog.Error("epss allowance enabled but no data exists")
-		return false
-	}
+// CVSS Limit
+//
+// Severity labels differ between producers, the CVSS base score is comparable across reports
 
-	badCVEs := make([]epss.CVE, 0)
+// cvssLimitExceeded the selected score if it is at or above the limit
+func cvssLimitExceeded(limit configCVSSLimit, scores []artifacts.CVSSScore) (artifacts.CVSSScore, bool) {
+	score, ok := artifacts.SelectCVSSScore(scores, limit.Version)
+	return score, ok && score.BaseScore >= limit.Score
+}
 
-	slog.Debug("run epss limit rule",
-		"artifact", "cyclonedx",
-		"vulnerabilities", len(report.Vulnerabilities),
-		"epss_limit_score", config.Cyclonedx.EPSSLimit.Score,
-	)
+func cvssThresholds(limit configCVSSLimit) map[string]any {
+	return map[string]any{"score": limit.Score, "version": limit.Version}
+}
+
+func cvssDetail(score artifacts.CVSSScore) string {
+	return fmt.Sprintf("cvss %s score %.1f", score.Version, score.BaseScore)
+}
+
+func ruleCVSSLimit(config reportWithCVEs, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleCVSSLimit, report.Artifact)
+	if !config.CVSSLimit.Enabled {
+		slog.Debug("cvss limit not enabled", "artifact", report.Artifact)
+		return result.skip()
+	}
+	result.Thresholds = cvssThresholds(config.CVSSLimit)
 
+	badCVEs := make([]Finding, 0)
 	for _, vulnerability := range report.Vulnerabilities {
-		epssCVE, ok := data.CVEs[vulnerability.ID]
-		if !ok {
+		score, exceeded := cvssLimitExceeded(config.CVSSLimit, vulnerability.CVSSScores)
+		if !exceeded {
 			continue
 		}
-		// add to badCVEs if the score is higher than the limit
-		if epssCVE.EPSSValue() > config.Cyclonedx.EPSSLimit.Score {
-			badCVEs = append(badCVEs, epssCVE)
-			slog.Warn(
-				"epss score limit violation",
-				"cve_id", vulnerability.ID,
-				"severity", vulnerability.HighestSeverity(),
-				"epss_score", epssCVE.EPSS,
-			)
-		}
+		slog.Warn("cvss score limit violation", "artifact", report.Artifact, "cve_id", vulnerability.ID,
+			"cvss_version", score.Version, "cvss_score", score.BaseScore)
+		badCVEs = append(badCVEs, vulnerability.findingWithDetail(cvssDetail(score)))
 	}
 	if len(badCVEs) > 0 {
-		slog.Error("cve(s) with epss scores over limit",
-			"over_limit_cves", len(badCVEs),
-			"epss_limit_score", config.Cyclonedx.EPSSLimit.Score,
-		)
-		return false
+		slog.Error("cve(s) with cvss scores over limit", "artifact", report.Artifact,
+			"over_limit_cves", len(badCVEs), "cvss_limit_score", config.CVSSLimit.Score)
+		return result.fail("CVSS Limit Exceeded", badCVEs...)
 	}
-	return true
+	return result
+}
+
+// Fix Availability
+//
+// Vulnerabilities without a fix are removed before the EPSS and severity limits
+// and set aside, not risk accepted, so they can be limited separately
+
+func ruleFixAvailable(config reportWithCVEs, report *vulnerabilityReport) RuleResult {
+	result := newRuleResult(RuleFixAvailable, report.Artifact)
+	if !config.FixAvailability.FixableOnly {
+		slog.Debug("fixable only not enabled", "artifact", report.Artifact)
+		return result.skip()
+	}
+
+	report.Vulnerabilities = slices.DeleteFunc(report.Vulnerabilities, func(vulnerability vulnerability) bool {
+		if vulnerability.Fixable {
+			return false
+		}
+		slog.Info("no fix available, removing from the EPSS and severity limits",
+			"artifact", report.Artifact, "id", vulnerability.ID, "state", vulnerability.FixState)
+		result = result.setAside(vulnerability.findingWithDetail(vulnerability.FixState))
+		return true
+	})
+	return result
+}
+
+// ruleNotFixedSeverityLimit the severity limits for the vulnerabilities removed by the fix availability rule
+func ruleNotFixedSeverityLimit(config reportWithCVEs, notFixed []Finding, artifact string) RuleResult {
+	result := newRuleResult(RuleNotFixedSeverity, artifact)
+
+	limits := map[string]configLimit{
+		"critical": config.FixAvailability.NotFixedSeverityLimit.Critical,
+		"high":     config.FixAvailability.NotFixedSeverityLimit.High,
+		"medium":   config.FixAvailability.NotFixedSeverityLimit.Medium,
+		"low":      config.FixAvailability.NotFixedSeverityLimit.Low,
+	}
+	result.Thresholds = severityThresholds(limits)
+	if !config.FixAvailability.FixableOnly || len(result.Thresholds) == 0 {
+		return result.skip()
+	}
+
+	for _, severity := range []string{"critical", "high", "medium", "low"} {
+		findings := slices.DeleteFunc(slices.Clone(notFixed), func(finding Finding) bool {
+			return !strings.EqualFold(finding.Severity, severity)
+		})
+		configuredLimit := limits[severity]
+		if !configuredLimit.Enabled {
+			continue
+		}
+		if len(findings) > int(configuredLimit.Limit) {
+			slog.Error("not fixed severity limit exceeded", "artifact", artifact, "severity", severity, "report", len(findings), "limit", configuredLimit.Limit)
+			result = result.fail("Not Fixed Severity Limit Exceeded", findings...)
+			continue
+		}
+		slog.Info("not fixed severity limit valid", "artifact", artifact, "severity", severity, "reported", len(findings), "limit", configuredLimit.Limit)
+	}
+	return result
 }
 
 func removeIgnoredSemgrepIssues(config *Config, report *artifacts.SemgrepReportMin) {
@@ -482,7 +608,25 @@ func removeIgnoredSemgrepIssues(config *Config, report *artifacts.SemgrepReportM
 	}
 }
 
-func ruleSemgrepSeverityLimit(config *Config, report *artifacts.SemgrepReportMin) bool {
+func semgrepFinding(result artifacts.SemgrepResults) Finding {
+	return Finding{
+		ID:       result.CheckID,
+		Severity: result.Extra.Severity,
+		Path:     result.Path,
+		Line:     result.Start.Line,
+		Detail:   result.Extra.Message,
+	}
+}
+
+func gitleaksFinding(finding artifacts.GitleaksFinding) Finding {
+	return Finding{
+		ID:   finding.RuleID,
+		Path: finding.File,
+		Line: finding.StartLine,
+	}
+}
+
+func ruleSemgrepSeverityLimit(config *Config, report *artifacts.SemgrepReportMin) RuleResult {
 	slog.Debug(
 		"severity limit rule", "artifact", "semgrep",
 		"error_enabled", config.Semgrep.SeverityLimit.Error.Enabled,
@@ -490,13 +634,17 @@ func ruleSemgrepSeverityLimit(config *Config, report *artifacts.SemgrepReportMin
 		"warning_enabled", config.Semgrep.SeverityLimit.Warning.Enabled,
 	)
 
-	validationPass := true
+	result := newRuleResult(RuleSeverityLimit, "semgrep")
 
 	limits := map[string]configLimit{
 		"error":   config.Semgrep.SeverityLimit.Error,
 		"warning": config.Semgrep.SeverityLimit.Warning,
 		"info":    config.Semgrep.SeverityLimit.Info,
 	}
+	result.Thresholds = severityThresholds(limits)
+	if len(result.Thresholds) == 0 {
+		result = result.skip()
+	}
 
 	for _, severity := range []string{"error", "warning", "info"} {
 
@@ -509,19 +657,21 @@ func ruleSemgrepSeverityLimit(config *Config, report *artifacts.SemgrepReportMin
 		}
 		if matchCount > int(configuredLimit.Limit) {
 			slog.Error("severity limit exceeded", "artifact", "semgrep", "severity", severity, "report", matchCount, "limit", configuredLimit.Limit)
+			findings := make([]Finding, 0, matchCount)
 			for _, match := range matches {
 				slog.Info("Potential issue detected", "severity", match.Extra.Severity, "check_id", match.CheckID, "message", match.Extra.Message)
+				findings = append(findings, semgrepFinding(match))
 			}
-			validationPass = false
+			result = result.fail("Severity Limit Exceeded", findings...)
 			continue
 		}
 		slog.Info("severity limit valid", "artifact", "semgrep", "severity", severity, "reported", matchCount, "limit", configuredLimit.Limit)
 	}
 
-	return validationPass
+	return result
 }
 
-func ruleSemgrepImpactRiskAccept(config *Config, report *artifacts.SemgrepReportMin) {
+func ruleSemgrepImpactRiskAccept(config *Config, report *artifacts.SemgrepReportMin) RuleResult {
 	slog.Debug(
 		"impact risk accept rule", "artifact", "semgrep",
 		"enabled", config.Semgrep.ImpactRiskAcceptance.Enabled,
@@ -530,49 +680,328 @@ func ruleSemgrepImpactRiskAccept(config *Config, report *artifacts.SemgrepReport
 		"low", config.Semgrep.ImpactRiskAcceptance.Low,
 	)
 
+	result := newRuleResult(RuleImpactRiskAcceptance, "semgrep")
+
 	if !config.Semgrep.ImpactRiskAcceptance.Enabled {
 		slog.Debug("impact risk acceptance not enabled", "artifact", "semgrep")
-		return
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{
+		"high":   config.Semgrep.ImpactRiskAcceptance.High,
+		"medium": config.Semgrep.ImpactRiskAcceptance.Medium,
+		"low":    config.Semgrep.ImpactRiskAcceptance.Low,
 	}
 
-	results := slices.DeleteFunc(report.Results, func(result artifacts.SemgrepResults) bool {
+	results := slices.DeleteFunc(report.Results, func(semgrepResult artifacts.SemgrepResults) bool {
 		riskAccepted := false
 		// TODO: make the configuration for risk acceptance less dumb (what would you accept high medium impact and not accept low impact)
 		switch {
-		case config.Semgrep.ImpactRiskAcceptance.High && strings.EqualFold(result.Extra.Metadata.Impact, "high"):
+		case config.Semgrep.ImpactRiskAcceptance.High && strings.EqualFold(semgrepResult.Extra.Metadata.Impact, "high"):
 			riskAccepted = true
-		case config.Semgrep.ImpactRiskAcceptance.Medium && strings.EqualFold(result.Extra.Metadata.Impact, "medium"):
+		case config.Semgrep.ImpactRiskAcceptance.Medium && strings.EqualFold(semgrepResult.Extra.Metadata.Impact, "medium"):
 			riskAccepted = true
-		case config.Semgrep.ImpactRiskAcceptance.Low && strings.EqualFold(result.Extra.Metadata.Impact, "low"):
+		case config.Semgrep.ImpactRiskAcceptance.Low && strings.EqualFold(semgrepResult.Extra.Metadata.Impact, "low"):
 			riskAccepted = true
 		}
 
 		if riskAccepted {
 			slog.Info(
 				"risk accepted: Semgrep issue impact is below acceptance threshold",
-				"check_id", result.CheckID,
-				"severity", result.Extra.Severity,
-				"impact", result.Extra.Metadata.Impact,
+				"check_id", semgrepResult.CheckID,
+				"severity", semgrepResult.Extra.Severity,
+				"impact", semgrepResult.Extra.Metadata.Impact,
 			)
+			finding := semgrepFinding(semgrepResult)
+			finding.Detail = fmt.Sprintf("impact %s", semgrepResult.Extra.Metadata.Impact)
+			result = result.accept(finding)
 			return true
 		}
 		return false
 	})
 
 	report.Results = results
+	return result
 }
 
-func ruleGitLeaksLimit(config *Config, report *artifacts.GitLeaksReportMin) bool {
+func ruleGitLeaksLimit(config *Config, report *artifacts.GitLeaksReportMin) RuleResult {
+	result := newRuleResult(RuleSecretsLimit, "gitleaks")
 	if !config.Gitleaks.LimitEnabled {
 		slog.Debug("secrets limit not enabled", "artifact", "gitleaks")
-		return true
+		return result.skip()
 	}
+	result.Thresholds = map[string]any{"limit": 0}
 	detectedSecrets := report.Count()
 	if detectedSecrets > 0 {
 		slog.Error("committed secrets violation", "artifacts", "gitleaks", "secrets_detected", detectedSecrets)
-		return false
+		findings := make([]Finding, 0, detectedSecrets)
+		for _, finding := range *report {
+			findings = append(findings, gitleaksFinding(finding))
+		}
+		return result.fail("Secrets Detected", findings...)
 	}
-	return true
+	return result
+}
+
+func sarifFinding(run artifacts.SarifRun, result artifacts.SarifResult) Finding {
+	uri, line := result.Location()
+	return Finding{
+		ID:       result.RuleID,
+		Severity: run.ResultLevel(result),
+		Package:  run.Tool.Driver.Name,
+		Path:     uri,
+		Line:     line,
+		Detail:   result.Message.Text,
+	}
+}
+
+// removeOutOfScopeSarifRuns keeps only the runs from configured tools
+func removeOutOfScopeSarifRuns(config *Config, report *artifacts.SarifReportMin) {
+	if len(config.Sarif.Tools) == 0 {
+		return
+	}
+	report.Runs = slices.DeleteFunc(report.Runs, func(run artifacts.SarifRun) bool {
+		inScope := slices.ContainsFunc(config.Sarif.Tools, func(tool string) bool {
+			return strings.EqualFold(tool, run.Tool.Driver.Name)
+		})
+		if !inScope {
+			slog.Debug("sarif run out of scope, skip", "tool", run.Tool.Driver.Name, "results", len(run.Results))
+		}
+		return !inScope
+	})
+}
+
+func ruleSarifRuleDeny(config *Config, report *artifacts.SarifReportMin) RuleResult {
+	result := newRuleResult(RuleRuleDeny, "sarif")
+	if !config.Sarif.RuleLimit.Enabled {
+		slog.Debug("rule id limits not enabled", "artifact", "sarif", "count_denied", len(config.Sarif.RuleLimit.RuleIDs))
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{"denied": len(config.Sarif.RuleLimit.RuleIDs)}
+	deniedResults := make([]Finding, 0)
+	for _, run := range report.Runs {
+		for _, sarifResult := range run.Results {
+			if sarifResult.IsSuppressed() {
+				continue
+			}
+			denied := slices.ContainsFunc(config.Sarif.RuleLimit.RuleIDs, func(ruleID string) bool {
+				return strings.EqualFold(ruleID, sarifResult.RuleID)
+			})
+			if denied {
+				slog.Error("rule id matched to Deny List", "artifact", "sarif", "tool", run.Tool.Driver.Name, "rule_id", sarifResult.RuleID)
+				deniedResults = append(deniedResults, sarifFinding(run, sarifResult))
+			}
+		}
+	}
+	if len(deniedResults) > 0 {
+		return result.fail("Rule ID explicitly denied", deniedResults...)
+	}
+	return result
+}
+
+func ruleSarifRuleAllow(config *Config, report *artifacts.SarifReportMin) RuleResult {
+	result := newRuleResult(RuleRuleAllow, "sarif")
+	slog.Debug("rule id risk acceptance rule", "artifact", "sarif",
+		"enabled", config.Sarif.RuleRiskAcceptance.Enabled,
+		"risk_accepted_rules", len(config.Sarif.RuleRiskAcceptance.RuleIDs),
+	)
+	if !config.Sarif.RuleRiskAcceptance.Enabled {
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{"accepted": len(config.Sarif.RuleRiskAcceptance.RuleIDs)}
+	for i, run := range report.Runs {
+		report.Runs[i].Results = slices.DeleteFunc(run.Results, func(sarifResult artifacts.SarifResult) bool {
+			allowed := slices.ContainsFunc(config.Sarif.RuleRiskAcceptance.RuleIDs, func(ruleID string) bool {
+				return strings.EqualFold(ruleID, sarifResult.RuleID)
+			})
+			if allowed {
+				slog.Info("rule id explicitly allowed, removing from subsequent rules",
+					"tool", run.Tool.Driver.Name, "rule_id", sarifResult.RuleID)
+				result = result.accept(sarifFinding(run, sarifResult))
+			}
+			return allowed
+		})
+	}
+	return result
+}
+
+func ruleSarifLevelLimit(config *Config, report *artifacts.SarifReportMin) RuleResult {
+	result := newRuleResult(RuleSeverityLimit, "sarif")
+
+	limits := map[string]configLimit{
+		"error":   config.Sarif.LevelLimit.Error,
+		"warning": config.Sarif.LevelLimit.Warning,
+		"note":    config.Sarif.LevelLimit.Note,
+	}
+	result.Thresholds = severityThresholds(limits)
+	if len(result.Thresholds) == 0 {
+		result = result.skip()
+	}
+
+	for _, level := range []string{"error", "warning", "note"} {
+		configuredLimit := limits[level]
+		if !configuredLimit.Enabled {
+			slog.Debug("level limit not enabled", "artifact", "sarif", "level", level)
+			continue
+		}
+		findings := make([]Finding, 0)
+		for _, run := range report.Runs {
+			for _, sarifResult := range run.Results {
+				if sarifResult.IsSuppressed() || run.ResultLevel(sarifResult) != level {
+					continue
+				}
+				findings = append(findings, sarifFinding(run, sarifResult))
+			}
+		}
+		if len(findings) > int(configuredLimit.Limit) {
+			slog.Error("level limit exceeded", "artifact", "sarif", "level", level, "report", len(findings), "limit", configuredLimit.Limit)
+			result = result.fail("Level Limit Exceeded", findings...)
+			continue
+		}
+		slog.Info("level limit valid", "artifact", "sarif", "level", level, "reported", len(findings), "limit", configuredLimit.Limit)
+	}
+
+	return result
+}
+
+func sbomFinding(pkg artifacts.SBOMPackage) Finding {
+	return Finding{
+		ID:      cmp.Or(pkg.PURL, pkg.Name),
+		Package: pkg.Name,
+		Version: pkg.Version,
+	}
+}
+
+func ruleSBOMPackageDeny(config *Config, packages []artifacts.SBOMPackage, artifact string) RuleResult {
+	result := newRuleResult(RulePackageDeny, artifact)
+	if !config.SBOM.PackageLimit.Enabled {
+		slog.Debug("package limits not enabled", "artifact", artifact, "count_denied", len(config.SBOM.PackageLimit.Packages))
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{"denied": len(config.SBOM.PackageLimit.Packages)}
+	deniedPackages := make([]Finding, 0)
+	for _, pkg := range packages {
+		denied := slices.ContainsFunc(config.SBOM.PackageLimit.Packages, func(deniedPkg configSBOMPackage) bool {
+			return strings.EqualFold(deniedPkg.Name, pkg.Name) && (deniedPkg.Version == "" || deniedPkg.Version == pkg.Version)
+		})
+		if denied {
+			slog.Error("package matched to Deny List", "artifact", artifact, "name", pkg.Name, "version", pkg.Version)
+			deniedPackages = append(deniedPackages, sbomFinding(pkg))
+		}
+	}
+	if len(deniedPackages) > 0 {
+		return result.fail("Package explicitly denied", deniedPackages...)
+	}
+	return result
+}
+
+func ruleSBOMRequireVersion(config *Config, packages []artifacts.SBOMPackage, artifact string) RuleResult {
+	result := newRuleResult(RuleRequireVersion, artifact)
+	if !config.SBOM.RequireVersion {
+		slog.Debug("require version not enabled", "artifact", artifact)
+		return result.skip()
+	}
+	missing := make([]Finding, 0)
+	for _, pkg := range packages {
+		if strings.TrimSpace(pkg.Version) == "" {
+			slog.Warn("component has no version", "artifact", artifact, "name", pkg.Name, "type", pkg.Type)
+			missing = append(missing, sbomFinding(pkg))
+		}
+	}
+	if len(missing) > 0 {
+		slog.Error("component(s) missing a version", "artifact", artifact, "missing", len(missing), "components", len(packages))
+		return result.fail("Component Version Missing", missing...)
+	}
+	return result
+}
+
+func ruleSBOMRequirePURL(config *Config, packages []artifacts.SBOMPackage, artifact string) RuleResult {
+	result := newRuleResult(RuleRequirePURL, artifact)
+	if !config.SBOM.RequirePURL {
+		slog.Debug("require purl not enabled", "artifact", artifact)
+		return result.skip()
+	}
+	missing := make([]Finding, 0)
+	for _, pkg := range packages {
+		if strings.TrimSpace(pkg.PURL) == "" {
+			slog.Warn("component has no purl", "artifact", artifact, "name", pkg.Name, "type", pkg.Type)
+			missing = append(missing, sbomFinding(pkg))
+		}
+	}
+	if len(missing) > 0 {
+		slog.Error("component(s) missing a purl", "artifact", artifact, "missing", len(missing), "components", len(packages))
+		return result.fail("Component PURL Missing", missing...)
+	}
+	return result
+}
+
+func ruleSBOMComponentLimit(config *Config, packages []artifacts.SBOMPackage, artifact string) RuleResult {
+	result := newRuleResult(RuleComponentLimit, artifact)
+	if !config.SBOM.ComponentLimit.Enabled {
+		slog.Debug("component limit not enabled", "artifact", artifact, "components", len(packages))
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{"limit": config.SBOM.ComponentLimit.Limit}
+	if len(packages) > int(config.SBOM.ComponentLimit.Limit) {
+		slog.Error("component limit exceeded", "artifact", artifact, "components", len(packages), "limit", config.SBOM.ComponentLimit.Limit)
+		return result.fail("Component Limit Exceeded", Finding{ID: "components", Detail: fmt.Sprintf("%d components", len(packages))})
+	}
+	slog.Info("component limit valid", "artifact", artifact, "components", len(packages), "limit", config.SBOM.ComponentLimit.Limit)
+	return result
+}
+
+func ruleSBOMLicenses(config *Config, packages []artifacts.SBOMPackage, artifact string) RuleResult {
+	result := newRuleResult(RuleLicense, artifact)
+	if !config.Licenses.Enabled {
+		slog.Debug("license compliance not enabled", "artifact", artifact)
+		return result.skip()
+	}
+	result.Thresholds = map[string]any{
+		"allowed":    len(config.Licenses.Allowed),
+		"denied":     len(config.Licenses.Denied),
+		"exceptions": len(config.Licenses.Exceptions),
+	}
+
+	badPackages := make([]Finding, 0)
+	for _, pkg := range packages {
+		excepted := slices.ContainsFunc(config.Licenses.Exceptions, func(exception configSBOMPackage) bool {
+			return strings.EqualFold(exception.Name, pkg.Name) && (exception.Version == "" || exception.Version == pkg.Version)
+		})
+
+		licenses := slices.DeleteFunc(slices.Clone(pkg.Licenses), func(license string) bool {
+			return slices.ContainsFunc(unknownLicenses, func(unknown string) bool {
+				return strings.EqualFold(unknown, strings.TrimSpace(license))
+			})
+		})
+
+		// Every license on a component applies, so all of them must be permitted
+		denied := slices.DeleteFunc(slices.Clone(licenses), func(license string) bool {
+			return licenseExpressionPermitted(config, license)
+		})
+
+		finding := sbomFinding(pkg)
+		switch {
+		case len(licenses) == 0:
+			finding.Detail = "missing license"
+		case len(denied) > 0:
+			finding.Detail = fmt.Sprintf("denied license %s", strings.Join(denied, ", "))
+		default:
+			continue
+		}
+
+		if excepted {
+			slog.Info("license exception, component skipped", "artifact", artifact, "name", pkg.Name, "version", pkg.Version, "reason", finding.Detail)
+			result = result.accept(finding)
+			continue
+		}
+		slog.Warn("license violation", "artifact", artifact, "name", pkg.Name, "version", pkg.Version, "reason", finding.Detail)
+		badPackages = append(badPackages, finding)
+	}
+
+	if len(badPackages) > 0 {
+		slog.Error("component(s) with denied or missing licenses", "artifact", artifact, "violations", len(badPackages), "components", len(packages))
+		return result.fail("License Denied or Missing", badPackages...)
+	}
+	return result
 }
 
 func loadCatalogFromFileOrAPI(catalog *kev.Catalog, options *fetchOptions) error {
@@ -584,7 +1013,7 @@ func loadCatalogFromFileOrAPI(catalog *kev.Catalog, options *fetchOptions) error
 
 	slog.Debug("load kev catalog from API")
 	err := kev.FetchData(catalog, kev.WithClient(options.kevClient), kev.WithURL(options.kevURL))
-	return err
+	return checkOffline(err, "kev")
 }
 
 func loadDataFromFileOrAPI(epssData *epss.Data, options *fetchOptions) error {
@@ -594,13 +1023,15 @@ func loadDataFromFileOrAPI(epssData *epss.Data, options *fetchOptions) error {
 	}
 
 	slog.Debug("load epss data from API")
-	err := epss.FetchData(epssData, epss.WithClient(options.epssClient), epss.WithURL(options.epssURL))
+	err := epss.FetchData(epssData, epss.WithClient(options.epssClient), epss.WithURL(options.epssURL), epss.WithDate(options.epssDate))
 
-	return err
+	return checkOffline(err, "epss")
 }
 
 func LoadCatalogAndData(config *Config, catalog *kev.Catalog, epssData *epss.Data, options *fetchOptions) error {
-	if config.Grype.KEVLimitEnabled || config.Cyclonedx.KEVLimitEnabled {
+	riskKEVNeeded, riskEPSSNeeded := riskDataNeeded(config)
+
+	if config.Grype.KEVLimitEnabled || config.Cyclonedx.KEVLimitEnabled || config.Trivy.KEVLimitEnabled || riskKEVNeeded {
 		if err := loadCatalogFromFileOrAPI(catalog, options); err != nil {
 			return err
 		}
@@ -608,8 +1039,9 @@ func LoadCatalogAndData(config *Config, catalog *kev.Catalog, epssData *epss.Dat
 
 	grypeEPSSNeeded := config.Grype.EPSSLimit.Enabled || config.Grype.EPSSRiskAcceptance.Enabled
 	cyclonedxEPSSNeeded := config.Cyclonedx.EPSSLimit.Enabled || config.Cyclonedx.EPSSRiskAcceptance.Enabled
+	trivyEPSSNeeded := config.Trivy.EPSSLimit.Enabled || config.Trivy.EPSSRiskAcceptance.Enabled
 
-	if grypeEPSSNeeded || cyclonedxEPSSNeeded {
+	if grypeEPSSNeeded || cyclonedxEPSSNeeded || trivyEPSSNeeded || riskEPSSNeeded {
 		if err := loadDataFromFileOrAPI(epssData, options); err != nil {
 			return err
 		}
@@ -619,30 +1051,30 @@ func LoadCatalogAndData(config *Config, catalog *kev.Catalog, epssData *epss.Dat
 
 // Validate Reports
 
-func validateGrypeReportWithFetch(r io.Reader, config *Config, options *fetchOptions) error {
+func validateGrypeReportWithFetch(r io.Reader, config *Config, options *fetchOptions) (*ArtifactResult, error) {
 	catalog := kev.NewCatalog()
 	epssData := new(epss.Data)
 
 	if err := LoadCatalogAndData(config, catalog, epssData, options); err != nil {
 		slog.Error("validate grype report: load epss data from file or api", "error", err)
-		return errors.New("cannot run Grype validation: Cannot load external validation data, see log for details")
+		return nil, errors.New("cannot run Grype validation: Cannot load external validation data, see log for details")
 	}
 
-	return validateGrypeFrom(r, config, catalog, epssData)
+	return validateGrypeFrom(r, config, catalog, epssData, options)
 }
 
-func validateGrypeFrom(r io.Reader, config *Config, catalog *kev.Catalog, epssData *epss.Data) error {
+func validateGrypeFrom(r io.Reader, config *Config, catalog *kev.Catalog, epssData *epss.Data, options *fetchOptions) (*ArtifactResult, error) {
 	slog.Debug("validate grype report")
 	report := &artifacts.GrypeReportMin{}
 	if err := json.NewDecoder(r).Decode(report); err != nil {
 		slog.Error("decode grype report for validation", "error", err)
-		return errors.New("cannot run Grype validation: Report decoding failed, See log for details")
+		return nil, errors.New("cannot run Grype validation: Report decoding failed, See log for details")
 	}
 
-	return validateGrypeRules(config, report, catalog, epssData)
+	return validateGrypeRules(config, report, catalog, epssData, options.baseline, options.failFast), nil
 }
 
-func validateCyclonedxReportWithFetch(r io.Reader, config *Config, options *fetchOptions) error {
+func validateCyclonedxReportWithFetch(r io.Reader, config *Config, options *fetchOptions) (*ArtifactResult, error) {
 	slog.Debug("validate cyclonedx report")
 
 	catalog := kev.NewCatalog()
@@ -650,52 +1082,90 @@ func validateCyclonedxReportWithFetch(r io.Reader, config *Config, options *fetc
 
 	if err := LoadCatalogAndData(config, catalog, epssData, options); err != nil {
 		slog.Error("validate cyclonedx report: load epss data from file or api", "error", err)
-		return errors.New("cannot run Cyclonedx validation: Cannot load external validation data, See log for details")
+		return nil, errors.New("cannot run Cyclonedx validation: Cannot load external validation data, See log for details")
 	}
-	return validateCyclonedxFrom(r, config, catalog, epssData)
+	return validateCyclonedxFrom(r, config, catalog, epssData, options)
 }
 
-func validateCyclonedxFrom(r io.Reader, config *Config, catalog *kev.Catalog, epssData *epss.Data) error {
+func validateCyclonedxFrom(r io.Reader, config *Config, catalog *kev.Catalog, epssData *epss.Data, options *fetchOptions) (*ArtifactResult, error) {
 	report := &artifacts.CyclonedxReportMin{}
 	if err := json.NewDecoder(r).Decode(report); err != nil {
 		slog.Error("decode cyclonedx report for validation", "error", err)
-		return errors.New("cannot run Cyclonedx validation: Report decoding failed, See log for details")
+		return nil, errors.New("cannot run Cyclonedx validation: Report decoding failed, See log for details")
 	}
 
-	return validateCyclonedxRules(config, report, catalog, epssData)
+	return validateCyclonedxRules(config, report, catalog, epssData, options.baseline, options.failFast), nil
 }
 
-func validateSemgrepReport(r io.Reader, config *Config) error {
+func validateTrivyReportWithFetch(r io.Reader, config *Config, options *fetchOptions) (*ArtifactResult, error) {
+	slog.Debug("validate trivy report")
+
+	catalog := kev.NewCatalog()
+	epssData := new(epss.Data)
+
+	if err := LoadCatalogAndData(config, catalog, epssData, options); err != nil {
+		slog.Error("validate trivy report: load epss data from file or api", "error", err)
+		return nil, errors.New("cannot run Trivy validation: Cannot load external validation data, See log for details")
+	}
+	return validateTrivyFrom(r, config, catalog, epssData, options)
+}
+
+func validateTrivyFrom(r io.Reader, config *Config, catalog *kev.Catalog, epssData *epss.Data, options *fetchOptions) (*ArtifactResult, error) {
+	report := &artifacts.TrivyReportMin{}
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		slog.Error("decode trivy report for validation", "error", err)
+		return nil, errors.New("cannot run Trivy validation: Report decoding failed, See log for details")
+	}
+
+	return validateTrivyRules(config, report, catalog, epssData, options.baseline, options.failFast), nil
+}
+
+func validateSyftReport(r io.Reader, config *Config, failFast bool) (*ArtifactResult, error) {
+	slog.Debug("validate syft report")
+	report := &artifacts.SyftReportMin{}
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		slog.Error("decode syft report for validation", "error", err)
+		return nil, errors.New("cannot run Syft validation: Report decoding failed, See log for details")
+	}
+	return validateSyftRules(config, report, failFast), nil
+}
+
+func validateSemgrepReport(r io.Reader, config *Config, base *baseline, failFast bool) (*ArtifactResult, error) {
 	slog.Debug("validate semgrep report")
 	report := &artifacts.SemgrepReportMin{}
 	if err := json.NewDecoder(r).Decode(report); err != nil {
 		slog.Error("decode semgrep report for validation", "error", err)
-		return errors.New("cannot run Semgrep report validation: Report decoding failed, See log for details")
+		return nil, errors.New("cannot run Semgrep report validation: Report decoding failed, See log for details")
 	}
 
-	return validateSemgrepRules(config, report)
+	return validateSemgrepRules(config, report, base, failFast), nil
 }
 
-func validateGitleaksReport(r io.Reader, config *Config) error {
+func validateGitleaksReport(r io.Reader, config *Config, base *baseline, failFast bool) (*ArtifactResult, error) {
 	slog.Debug("validate gitleaks report")
 	report := &artifacts.GitLeaksReportMin{}
 	if err := json.NewDecoder(r).Decode(report); err != nil {
 		slog.Error("decode gitleaks report for validation", "error", err)
-		return errors.New("cannot run Semgrep report validation: Report decoding failed, See log for details")
+		return nil, errors.New("cannot run Semgrep report validation: Report decoding failed, See log for details")
 	}
-	return validateGitleaksRules(config, report)
+	return validateGitleaksRules(config, report, base, failFast), nil
 }
 
-func validateCoverage(src io.Reader, targetFilename string, config *Config) error {
-	coverageFormat, err := artifacts.GetCoverageMode(targetFilename)
-	if err != nil {
-		return err
+func validateSarifReport(r io.Reader, config *Config, failFast bool) (*ArtifactResult, error) {
+	slog.Debug("validate sarif report")
+	report := &artifacts.SarifReportMin{}
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		slog.Error("decode sarif report for validation", "error", err)
+		return nil, errors.New("cannot run SARIF report validation: Report decoding failed, See log for details")
 	}
+	return validateSarifRules(config, report, failFast), nil
+}
 
+func validateCoverage(src io.Reader, coverageFormat coverage.CoverageMode, config *Config, failFast bool) (*ArtifactResult, error) {
 	parser := coverage.New(coverageFormat)
 	report, err := parser.ParseReader(src)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	lineCoverage := float32(report.CoveredLines) / float32(report.TotalLines)
@@ -709,35 +1179,53 @@ func validateCoverage(src io.Reader, targetFilename string, config *Config) erro
 		"branch_coverage", branchCoverage,
 	)
 
-	var errs error
+	artifactResult := newArtifactResult("coverage")
 
+	lineResult := newRuleResult(RuleLineCoverage, "coverage")
+	lineResult.Thresholds = map[string]any{"threshold": config.Coverage.LineThreshold}
 	if lineCoverage < config.Coverage.LineThreshold {
 		slog.Error("line coverage below threshold", "line_coverage", lineCoverage, "threshold", config.Coverage.LineThreshold)
-		coverageErr := newValidationErr("Coverage: Line coverage below threshold")
-		errs = errors.Join(errs, coverageErr)
+		lineResult = lineResult.fail("Line coverage below threshold", Finding{ID: "line", Detail: fmt.Sprintf("%f", lineCoverage)})
+	}
+	if !artifactResult.add(lineResult).Passed() && failFast {
+		return artifactResult, nil
 	}
 
+	functionResult := newRuleResult(RuleFunctionCoverage, "coverage")
+	functionResult.Thresholds = map[string]any{"threshold": config.Coverage.FunctionThreshold}
 	if functionCoverage < config.Coverage.FunctionThreshold {
 		slog.Error("function coverage below threshold", "function_coverage", functionCoverage, "threshold", config.Coverage.FunctionThreshold)
-		coverageErr := newValidationErr("Coverage: Function coverage below threshold")
-		errs = errors.Join(errs, coverageErr)
+		functionResult = functionResult.fail("Function coverage below threshold", Finding{ID: "function", Detail: fmt.Sprintf("%f", functionCoverage)})
+	}
+	if !artifactResult.add(functionResult).Passed() && failFast {
+		return artifactResult, nil
 	}
 
+	branchResult := newRuleResult(RuleBranchCoverage, "coverage")
+	branchResult.Thresholds = map[string]any{"threshold": config.Coverage.BranchThreshold}
 	if branchCoverage < config.Coverage.BranchThreshold {
 		slog.Error("branch coverage below threshold", "branch_coverage", branchCoverage, "threshold", config.Coverage.BranchThreshold)
-		coverageErr := newValidationErr("Coverage: Branch coverage below threshold")
-		errs = errors.Join(errs, coverageErr)
+		branchResult = branchResult.fail("Branch coverage below threshold", Finding{ID: "branch", Detail: fmt.Sprintf("%f", branchCoverage)})
 	}
+	artifactResult.add(branchResult)
 
-	return errs
+	return artifactResult, nil
 }
 
-func validateBundle(r io.Reader, config *Config, options *fetchOptions) error {
+func validateBundle(r io.Reader, config *Config, options *fetchOptions) (*ValidationResult, error) {
 	slog.Debug("validate gatecheck bundle")
+	result := &ValidationResult{Artifacts: make([]*ArtifactResult, 0)}
 	bundle := archive.NewBundle()
 	if err := archive.UntarGzipBundle(r, bundle); err != nil {
-		slog.Error("decode gatecheck bundle")
-		return errors.New("cannot run Gatecheck Bundle validation: Bundle decoding failed, See log for details")
+		slog.Error("decode gatecheck bundle", "error", err)
+		return result, errors.New("cannot run Gatecheck Bundle validation: Bundle decoding failed, See log for details")
+	}
+
+	if options.requireSignature {
+		if err := verifyBundleSignature(bundle, options.signatureKeyFile); err != nil {
+			slog.Error("gatecheck bundle signature verification", "error", err)
+			return result, errors.New("cannot run Gatecheck Bundle validation: Signature verification failed, See log for details")
+		}
 	}
 
 	catalog := kev.NewCatalog()
@@ -745,39 +1233,95 @@ func validateBundle(r io.Reader, config *Config, options *fetchOptions) error {
 
 	if err := LoadCatalogAndData(config, catalog, epssData, options); err != nil {
 		slog.Error("validate cyclonedx report: load epss data from file or api", "error", err)
-		return errors.New("cannot run Cyclonedx validation: Cannot load external validation data, See log for details")
+		return result, errors.New("cannot run Cyclonedx validation: Cannot load external validation data, See log for details")
 	}
 
 	var errs error
-	for fileLabel, descriptor := range bundle.Manifest().Files {
-		slog.Info("gatecheck bundle validation", "file_label", fileLabel, "digest", descriptor.Digest)
-		switch {
-		case strings.Contains(fileLabel, "grype"):
-			err := validateGrypeFrom(bytes.NewBuffer(bundle.FileBytes(fileLabel)), config, catalog, epssData)
-			errs = errors.Join(errs, err)
-		case strings.Contains(fileLabel, "cyclonedx"):
-			err := validateCyclonedxFrom(bytes.NewBuffer(bundle.FileBytes(fileLabel)), config, catalog, epssData)
-			errs = errors.Join(errs, err)
-		case strings.Contains(fileLabel, "semgrep"):
-			err := validateSemgrepReport(bytes.NewBuffer(bundle.FileBytes(fileLabel)), config)
-			errs = errors.Join(errs, err)
-		case strings.Contains(fileLabel, "gitleaks"):
-			err := validateGitleaksReport(bytes.NewBuffer(bundle.FileBytes(fileLabel)), config)
-			errs = errors.Join(errs, err)
-		case artifacts.IsCoverageReport(fileLabel):
-			err := validateCoverage(bytes.NewBuffer(bundle.FileBytes(fileLabel)), fileLabel, config)
+	for _, fileLabel := range bundle.Labels() {
+		slog.Info("gatecheck bundle validation", "file_label", fileLabel, "digest", bundle.Manifest().Files[fileLabel].Digest)
+		artifactType, src, err := detectArtifactType(fileLabel, bytes.NewReader(bundle.FileBytes(fileLabel)))
+		if err != nil {
+			slog.Debug("unsupported file type in bundle, skip", "file_label", fileLabel)
+			continue
+		}
+		var artifactResult *ArtifactResult
+		switch artifactType {
+		case artifacts.TypeSarif:
+			artifactResult, err = validateSarifReport(src, config, options.failFast)
+		case artifacts.TypeGrype:
+			artifactResult, err = validateGrypeFrom(src, config, catalog, epssData, options)
+		case artifacts.TypeCyclonedx:
+			artifactResult, err = validateCyclonedxFrom(src, config, catalog, epssData, options)
+		case artifacts.TypeTrivy:
+			artifactResult, err = validateTrivyFrom(src, config, catalog, epssData, options)
+		case artifacts.TypeSyft:
+			artifactResult, err = validateSyftReport(src, config, options.failFast)
+		case artifacts.TypeSemgrep:
+			artifactResult, err = validateSemgrepReport(src, config, options.baseline, options.failFast)
+		case artifacts.TypeGitleaks:
+			artifactResult, err = validateGitleaksReport(src, config, options.baseline, options.failFast)
+		case artifacts.TypeLCOV, artifacts.TypeCobertura, artifacts.TypeClover:
+			artifactResult, err = validateCoverage(src, coverage.CoverageMode(artifactType), config, options.failFast)
+		default:
+			continue
+		}
+		if err != nil {
 			errs = errors.Join(errs, err)
+			continue
 		}
+		artifactResult.Label = fileLabel
+		result.Artifacts = append(result.Artifacts, artifactResult)
 	}
+
+	errs = errors.Join(errs, result.Err())
 	if errs != nil {
-		return errors.Join(newValidationErr("Gatecheck Bundle"), errs)
+		return result, errors.Join(newValidationErr("Gatecheck Bundle"), errs)
+	}
+	return result, nil
+}
+
+func verifyBundleSignature(bundle *archive.Bundle, keyFile *os.File) error {
+	publicKey, err := readPublicKey(keyFile)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Verify(publicKey); err != nil {
+		return err
 	}
+	slog.Info("gatecheck bundle signature verified", "key_id", bundle.Signature().KeyID, "signed_at", bundle.Signature().SignedAt)
 	return nil
 }
 
 // Validate Rules
+//
+// Every rule is evaluated so all failures are reported together.
+// With failFast, evaluation stops at the first failing rule
+
+func validateGrypeRules(config *Config, report *artifacts.GrypeReportMin, catalog *kev.Catalog, data *epss.Data, base *baseline, failFast bool) *ArtifactResult {
+	return validateVulnerabilityRules(config.Grype, grypeVulnerabilities(report), catalog, data, base, failFast)
+}
+
+func validateCyclonedxRules(config *Config, report *artifacts.CyclonedxReportMin, catalog *kev.Catalog, data *epss.Data, base *baseline, failFast bool) *ArtifactResult {
+	result := validateVulnerabilityRules(config.Cyclonedx, cyclonedxVulnerabilities(report), catalog, data, base, failFast)
+	if !result.Passed() && failFast {
+		return result
+	}
+
+	// 8. SBOM component policy
+	return validateSBOMRules(config, report.SBOMPackages(), result, failFast)
+}
 
-func validateGrypeRules(config *Config, report *artifacts.GrypeReportMin, catalog *kev.Catalog, data *epss.Data) error {
+func validateTrivyRules(config *Config, report *artifacts.TrivyReportMin, catalog *kev.Catalog, data *epss.Data, base *baseline, failFast bool) *ArtifactResult {
+	return validateVulnerabilityRules(config.Trivy, trivyVulnerabilities(report), catalog, data, base, failFast)
+}
+
+// validateVulnerabilityRules the CVE rules for Grype, Cyclonedx and Trivy reports
+func validateVulnerabilityRules(config reportWithCVEs, report *vulnerabilityReport, catalog *kev.Catalog, data *epss.Data, base *baseline, failFast bool) *ArtifactResult {
+	result := newArtifactResult(report.Artifact)
+	result.EPSS = newEPSSSource(data)
+	for _, vulnerability := range report.Vulnerabilities {
+		result.Counts[vulnerability.Severity]++
+	}
 	severityRank := []string{
 		"critical",
 		"high",
@@ -786,100 +1330,172 @@ func validateGrypeRules(config *Config, report *artifacts.GrypeReportMin, catalo
 		"negligible",
 		"unknown",
 	}
-	sort.Slice(report.Matches, func(i, j int) bool {
-		if report.Matches[i].Vulnerability.Severity == report.Matches[j].Vulnerability.Severity {
-			epssi, oki := data.CVEs[report.Matches[i].Vulnerability.ID]
-			epssj, okj := data.CVEs[report.Matches[j].Vulnerability.ID]
+	epssCVEs := make(map[string]epss.CVE)
+	if data != nil {
+		epssCVEs = data.CVEs
+	}
+	sort.SliceStable(report.Vulnerabilities, func(i, j int) bool {
+		if report.Vulnerabilities[i].Severity == report.Vulnerabilities[j].Severity {
+			epssi, oki := epssCVEs[report.Vulnerabilities[i].ID]
+			epssj, okj := epssCVEs[report.Vulnerabilities[j].ID]
 
 			// Sort EPPS from highest to lowest
-			return !okj || oki && epssi.EPSSValue() > epssj.EPSSValue()
+			return oki && (!okj || epssi.EPSSValue() > epssj.EPSSValue())
 		}
-		ranki := slices.Index(severityRank, strings.ToLower(report.Matches[i].Vulnerability.Severity))
-		rankj := slices.Index(severityRank, strings.ToLower(report.Matches[j].Vulnerability.Severity))
+		ranki := slices.Index(severityRank, report.Vulnerabilities[i].Severity)
+		rankj := slices.Index(severityRank, report.Vulnerabilities[j].Severity)
 		return ranki < rankj
 	})
 	// 1. Deny List - Fail Matching
-	if !ruleGrypeCVEDeny(config, report) {
-		return newValidationErr("Grype: CVE explicitly denied")
+	if !result.add(ruleCVEDeny(config, report)).Passed() && failFast {
+		return result
 	}
 
 	// Ignore any CVEs that don't meet the vulnerability threshold or the EPPS threshold
-	removeIgnoredSeverityCVEs(config, report, data)
+	removeIgnoredSeverityCVEs(config, report, catalog, data)
+
+	// Baseline - remove findings from the previous report
+	result.add(ruleVulnerabilityBaseline(base, report))
 
 	// 2. CVE Allowance - remove from matches
-	ruleGrypeCVEAllow(config, report)
+	result.add(ruleCVEAllow(config, report))
 
 	// 3. KEV Catalog Limit - fail matching
-	if !ruleGrypeKEVLimit(config, report, catalog) {
-		return newValidationErr("Grype: CVE matched to KEV Catalog")
+	if !result.add(ruleKEVLimit(config, report, catalog)).Passed() && failFast {
+		return result
 	}
 
 	// 4. EPSS Allowance - remove from matches
-	ruleGrypeEPSSAllow(config, report, data)
+	result.add(ruleEPSSAllow(config, report, data))
+
+	// Fix Availability - remove vulnerabilities without a fix from the limits
+	notFixed := result.add(ruleFixAvailable(config, report)).SetAside
+
+	// 5. EPSS Limit - Fail Exceeding
+	if !result.add(ruleEPSSLimit(config, report, data)).Passed() && failFast {
+		return result
+	}
+
+	// CVSS Limit - Fail at or above the score
+	if !result.add(ruleCVSSLimit(config, report)).Passed() && failFast {
+		return result
+	}
 
-	// 5. EPSS Limit - Fail Exceeding TODO: Implement
-	if !ruleGrypeEPSSLimit(config, report, data) {
-		return newValidationErr("Grype: EPSS Limit Exceeded")
+	// Risk Score Limit - Fail above the weighted score
+	if !result.add(ruleRiskScoreLimit(config, report, catalog, data)).Passed() && failFast {
+		return result
 	}
 
 	// 6. Severity Count Limit
-	if !ruleGrypeSeverityLimit(config, report) {
-		return newValidationErr("Grype: Severity Limit Exceeded")
+	if !result.add(ruleSeverityLimit(config, report)).Passed() && failFast {
+		return result
 	}
 
-	return nil
+	// 7. Not Fixed Severity Count Limit
+	result.add(ruleNotFixedSeverityLimit(config, notFixed, report.Artifact))
+
+	return result
 }
 
-func validateCyclonedxRules(config *Config, report *artifacts.CyclonedxReportMin, catalog *kev.Catalog, data *epss.Data) error {
-	// 1. Deny List - Fail Matching
-	if !ruleCyclonedxCVEDeny(config, report) {
-		return newValidationErr("CycloneDx: CVE explicitly denied")
-	}
+func validateSyftRules(config *Config, report *artifacts.SyftReportMin, failFast bool) *ArtifactResult {
+	result := newArtifactResult("syft")
+	result.Counts["components"] = len(report.Artifacts)
 
-	// 2. CVE Allowance - remove from matches
-	ruleCyclonedxCVEAllow(config, report)
+	return validateSBOMRules(config, report.SBOMPackages(), result, failFast)
+}
 
-	// 3. KEV Catalog Limit - fail matching
-	if !ruleCyclonedxKEVLimit(config, report, catalog) {
-		return newValidationErr("CycloneDx: CVE Matched to KEV Catalog")
+// validateSBOMRules adds the component policy rules to an SBOM artifact result
+func validateSBOMRules(config *Config, packages []artifacts.SBOMPackage, result *ArtifactResult, failFast bool) *ArtifactResult {
+	// 1. Package Deny List - fail matching
+	if !result.add(ruleSBOMPackageDeny(config, packages, result.Type)).Passed() && failFast {
+		return result
 	}
 
-	// 4. EPSS Allowance - remove from matches
-	ruleCyclonedxEPSSAllow(config, report, data)
-
-	// 5. EPSS Limit - Fail Exceeding
-	if !ruleCyclonedxEPSSLimit(config, report, data) {
-		return newValidationErr("CycloneDx: EPSS Limit Exceeded")
+	// 2. Required Component Fields
+	if !result.add(ruleSBOMRequireVersion(config, packages, result.Type)).Passed() && failFast {
+		return result
+	}
+	if !result.add(ruleSBOMRequirePURL(config, packages, result.Type)).Passed() && failFast {
+		return result
 	}
 
-	// 6. Severity Count Limit
-	if !ruleCyclonedxSeverityLimit(config, report) {
-		return newValidationErr("CycloneDx: Severity Limit Exceeded")
+	// 3. Component Count Limit
+	if !result.add(ruleSBOMComponentLimit(config, packages, result.Type)).Passed() && failFast {
+		return result
 	}
 
-	return nil
+	// 4. License Compliance
+	result.add(ruleSBOMLicenses(config, packages, result.Type))
+
+	return result
 }
 
-func validateSemgrepRules(config *Config, report *artifacts.SemgrepReportMin) error {
+func validateSemgrepRules(config *Config, report *artifacts.SemgrepReportMin, base *baseline, failFast bool) *ArtifactResult {
 	slog.Info("validating semgrep rules", "findings", len(report.Results))
+	result := newArtifactResult("semgrep")
+	for _, semgrepResult := range report.Results {
+		result.Counts[strings.ToLower(semgrepResult.Extra.Severity)]++
+	}
+
 	// Ignore issues for which there is no severity limit
 	removeIgnoredSemgrepIssues(config, report)
 
+	// Baseline - remove findings from the previous report
+	result.add(ruleSemgrepBaseline(base, report))
+
 	// 1. Impact Allowance - remove result
-	ruleSemgrepImpactRiskAccept(config, report)
+	result.add(ruleSemgrepImpactRiskAccept(config, report))
 
 	// 2. Severity Count Limit
-	if !ruleSemgrepSeverityLimit(config, report) {
-		return newValidationErr("Semgrep: Severity Limit Exceeded")
+	if !result.add(ruleSemgrepSeverityLimit(config, report)).Passed() && failFast {
+		return result
 	}
 
-	return nil
+	return result
 }
 
-func validateGitleaksRules(config *Config, report *artifacts.GitLeaksReportMin) error {
+func validateGitleaksRules(config *Config, report *artifacts.GitLeaksReportMin, base *baseline, failFast bool) *ArtifactResult {
+	result := newArtifactResult("gitleaks")
+	result.Counts["secrets"] = report.Count()
+
+	// Baseline - remove findings from the previous report
+	result.add(ruleGitleaksBaseline(base, report))
+
 	// 1. Limit Secrets - fail
-	if !ruleGitLeaksLimit(config, report) {
-		return newValidationErr("Gitleaks: Secrets Detected")
+	if !result.add(ruleGitLeaksLimit(config, report)).Passed() && failFast {
+		return result
 	}
-	return nil
+
+	return result
+}
+
+func validateSarifRules(config *Config, report *artifacts.SarifReportMin, failFast bool) *ArtifactResult {
+	result := newArtifactResult("sarif")
+
+	// Only validate runs from the configured tools
+	removeOutOfScopeSarifRuns(config, report)
+
+	for _, run := range report.Runs {
+		for _, sarifResult := range run.Results {
+			if sarifResult.IsSuppressed() {
+				continue
+			}
+			result.Counts[run.ResultLevel(sarifResult)]++
+		}
+	}
+
+	// 1. Deny List - Fail Matching
+	if !result.add(ruleSarifRuleDeny(config, report)).Passed() && failFast {
+		return result
+	}
+
+	// 2. Rule Allowance - remove from results
+	result.add(ruleSarifRuleAllow(config, report))
+
+	// 3. Level Count Limit
+	if !result.add(ruleSarifLevelLimit(config, report)).Passed() && failFast {
+		return result
+	}
+
+	return result
 }
@@ -1,12 +1,28 @@
 package gatecheck
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gatecheckdev/gatecheck/pkg/archive"
 	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+	"github.com/gatecheckdev/gatecheck/pkg/epss"
+	"github.com/gatecheckdev/gatecheck/pkg/kev"
 	"github.com/lmittmann/tint"
 )
 
@@ -20,13 +36,13 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
-func Test_ruleGrypeSeverityLimit(t *testing.T) {
+func Test_ruleSeverityLimit_grype(t *testing.T) {
 	t.Run("empty-report-empty-config", func(t *testing.T) {
 		config := new(Config)
 		report := new(artifacts.GrypeReportMin)
 
 		want := true
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -40,7 +56,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 		report := new(artifacts.GrypeReportMin)
 
 		want := true
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -57,7 +73,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -84,7 +100,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -111,7 +127,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 		}
 
 		want := true
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -138,7 +154,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleGrypeSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Grype, grypeVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -158,7 +174,7 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 
 		want := true
 		got := false
-		err := validateGrypeRules(config, report, nil, nil)
+		err := validateGrypeRules(config, report, nil, nil, nil, false).Err()
 		if err == nil {
 			got = true
 		}
@@ -169,13 +185,13 @@ func Test_ruleGrypeSeverityLimit(t *testing.T) {
 	})
 }
 
-func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
+func Test_ruleSeverityLimit_cyclonedx(t *testing.T) {
 	t.Run("empty-report-empty-config", func(t *testing.T) {
 		config := new(Config)
 		report := new(artifacts.CyclonedxReportMin)
 
 		want := true
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -189,7 +205,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 		report := new(artifacts.CyclonedxReportMin)
 
 		want := true
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -206,7 +222,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -233,7 +249,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -260,7 +276,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 		}
 
 		want := true
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -287,7 +303,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 		}
 
 		want := false
-		got := ruleCyclonedxSeverityLimit(config, report)
+		got := ruleSeverityLimit(config.Cyclonedx, cyclonedxVulnerabilities(report)).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -310,7 +326,7 @@ func Test_ruleCyclonedxSeverityLimit(t *testing.T) {
 
 		want := true
 		got := false
-		err := validateCyclonedxRules(config, report, nil, nil)
+		err := validateCyclonedxRules(config, report, nil, nil, nil, false).Err()
 		if err == nil {
 			got = true
 		}
@@ -328,7 +344,7 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 
 		want := true
 
-		got := ruleSemgrepSeverityLimit(config, report)
+		got := ruleSemgrepSeverityLimit(config, report).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -343,7 +359,7 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 
 		want := true
 
-		got := ruleSemgrepSeverityLimit(config, report)
+		got := ruleSemgrepSeverityLimit(config, report).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -371,7 +387,7 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 
 		want := false
 
-		got := ruleSemgrepSeverityLimit(config, report)
+		got := ruleSemgrepSeverityLimit(config, report).Passed()
 
 		if want != got {
 			t.Fatalf("want: %t got: %t", want, got)
@@ -396,7 +412,7 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 		want := true
 		got := true
 
-		err := validateSemgrepRules(config, report)
+		err := validateSemgrepRules(config, report, nil, false).Err()
 		if err != nil {
 			got = false
 		}
@@ -425,7 +441,7 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 		want := false
 		got := true
 
-		err := validateSemgrepRules(config, report)
+		err := validateSemgrepRules(config, report, nil, false).Err()
 		if err != nil {
 			got = false
 		}
@@ -435,3 +451,976 @@ func Test_ruleSemgrepSeverityLimit(t *testing.T) {
 		}
 	})
 }
+
+func Test_validateSarifRules(t *testing.T) {
+	decodeReport := func(t *testing.T) *artifacts.SarifReportMin {
+		report := &artifacts.SarifReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/sarif-report.sarif", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+
+	t.Run("counts", func(t *testing.T) {
+		result := validateSarifRules(new(Config), decodeReport(t), false)
+		want := map[string]int{"error": 2, "warning": 1, "note": 1}
+		for level, count := range want {
+			if result.Counts[level] != count {
+				t.Fatalf("want: %d %s got: %v", count, level, result.Counts)
+			}
+		}
+		if !result.Passed() {
+			t.Fatalf("want: pass with no rules enabled got: %+v", result.Rules)
+		}
+	})
+
+	testTable := []struct {
+		label      string
+		configure  func(*Config)
+		wantPass   bool
+		wantFailed string
+	}{
+		{
+			label: "error-limit-exceeded",
+			configure: func(c *Config) {
+				c.Sarif.LevelLimit.Error = configLimit{Enabled: true, Limit: 1}
+			},
+			wantPass:   false,
+			wantFailed: RuleSeverityLimit,
+		},
+		{
+			label: "error-limit-tool-scoped",
+			configure: func(c *Config) {
+				c.Sarif.Tools = []string{"codeql"}
+				c.Sarif.LevelLimit.Error = configLimit{Enabled: true, Limit: 1}
+			},
+			wantPass: true,
+		},
+		{
+			label: "error-limit-rule-accepted",
+			configure: func(c *Config) {
+				c.Sarif.LevelLimit.Error = configLimit{Enabled: true, Limit: 1}
+				c.Sarif.RuleRiskAcceptance = configSarifRuleIDs{Enabled: true, RuleIDs: []string{"CKV_DOCKER_2"}}
+			},
+			wantPass: true,
+		},
+		{
+			label: "rule-denied",
+			configure: func(c *Config) {
+				c.Sarif.RuleLimit = configSarifRuleIDs{Enabled: true, RuleIDs: []string{"go/log-injection"}}
+			},
+			wantPass:   false,
+			wantFailed: RuleRuleDeny,
+		},
+		{
+			label: "suppressed-rule-denied",
+			configure: func(c *Config) {
+				c.Sarif.Tools = []string{"checkov"}
+				c.Sarif.RuleLimit = configSarifRuleIDs{Enabled: true, RuleIDs: []string{"go/sql-injection"}}
+			},
+			wantPass: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := NewDefaultConfig()
+			testCase.configure(config)
+			result := validateSarifRules(config, decodeReport(t), false)
+			if result.Passed() != testCase.wantPass {
+				t.Fatalf("want: pass %t got: %+v", testCase.wantPass, result.Rules)
+			}
+			if testCase.wantPass {
+				return
+			}
+			failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+			if len(failures) != 1 || failures[0].Rule != testCase.wantFailed {
+				t.Fatalf("want: %s failure got: %+v", testCase.wantFailed, failures)
+			}
+		})
+	}
+}
+
+func Test_validateTrivyRules(t *testing.T) {
+	decodeReport := func(t *testing.T) *artifacts.TrivyReportMin {
+		report := &artifacts.TrivyReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/trivy-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+	catalog := kev.NewCatalog()
+	if err := kev.DecodeData(MustOpen("../../test/known_exploited_vulnerabilities.json", t), catalog); err != nil {
+		t.Fatal(err)
+	}
+	data := &epss.Data{CVEs: map[string]epss.CVE{
+		"CVE-2021-44228": {EPSS: "0.97565", Percentile: "0.99996"},
+		"CVE-2024-2961":  {EPSS: "0.00043", Percentile: "0.10245"},
+		"CVE-2024-29041": {EPSS: "0.00045", Percentile: "0.15733"},
+	}}
+
+	t.Run("counts", func(t *testing.T) {
+		result := validateTrivyRules(new(Config), decodeReport(t), catalog, data, nil, false)
+		want := map[string]int{"critical": 2, "high": 2, "medium": 1, "low": 1}
+		for severity, count := range want {
+			if result.Counts[severity] != count {
+				t.Fatalf("want: %d %s got: %v", count, severity, result.Counts)
+			}
+		}
+	})
+
+	testTable := []struct {
+		label        string
+		configure    func(*Config)
+		wantFailed   []string
+		wantFindings int
+	}{
+		{
+			label: "critical-limit",
+			configure: func(c *Config) {
+				c.Trivy.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 0}
+			},
+			wantFailed:   []string{RuleSeverityLimit},
+			wantFindings: 2,
+		},
+		{
+			label: "kev-limit",
+			configure: func(c *Config) {
+				c.Trivy.KEVLimitEnabled = true
+				c.Trivy.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 2}
+				c.Trivy.SeverityLimit.High = configLimit{Enabled: true, Limit: 2}
+			},
+			wantFailed:   []string{RuleKEVLimit},
+			wantFindings: 2,
+		},
+		{
+			label: "kev-limit-cve-accepted",
+			configure: func(c *Config) {
+				c.Trivy.KEVLimitEnabled = true
+				c.Trivy.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 2}
+				c.Trivy.SeverityLimit.High = configLimit{Enabled: true, Limit: 2}
+				c.Trivy.CVERiskAcceptance = configCVERiskAcceptance{Enabled: true, CVEs: []configCVE{{ID: "CVE-2021-3156"}}}
+			},
+			wantFailed:   []string{RuleKEVLimit},
+			wantFindings: 1,
+		},
+		{
+			label: "epss-limit",
+			configure: func(c *Config) {
+				c.Trivy.EPSSLimit = configEPSSLimit{Enabled: true, Score: 0.5}
+			},
+			wantFailed:   []string{RuleEPSSLimit},
+			wantFindings: 1,
+		},
+		{
+			label: "high-limit-epss-accepted",
+			configure: func(c *Config) {
+				c.Trivy.SeverityLimit.High = configLimit{Enabled: true, Limit: 1}
+				c.Trivy.EPSSRiskAcceptance = configEPSSRiskAcceptance{Enabled: true, Score: 0.001}
+			},
+		},
+		{
+			label: "epss-percentile-limit",
+			configure: func(c *Config) {
+				c.Trivy.EPSSLimit = configEPSSLimit{Enabled: true, Percentile: 0.15}
+			},
+			wantFailed:   []string{RuleEPSSLimit},
+			wantFindings: 2,
+		},
+		{
+			label: "high-limit-epss-percentile-accepted",
+			configure: func(c *Config) {
+				c.Trivy.SeverityLimit.High = configLimit{Enabled: true, Limit: 1}
+				c.Trivy.EPSSRiskAcceptance = configEPSSRiskAcceptance{Enabled: true, Percentile: 0.2}
+			},
+		},
+		{
+			label: "cve-deny",
+			configure: func(c *Config) {
+				c.Trivy.CVELimit = configCVELimit{Enabled: true, CVEs: []configCVE{{ID: "cve-2024-29041"}}}
+			},
+			wantFailed:   []string{RuleCVEDeny},
+			wantFindings: 1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := NewDefaultConfig()
+			testCase.configure(config)
+			result := validateTrivyRules(config, decodeReport(t), catalog, data, nil, false)
+
+			failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+			gotFailed := make([]string, 0, len(failures))
+			gotFindings := 0
+			for _, failure := range failures {
+				gotFailed = append(gotFailed, failure.Rule)
+				gotFindings += len(failure.Findings)
+			}
+			if !slices.Equal(testCase.wantFailed, gotFailed) {
+				t.Fatalf("want: %v got: %v", testCase.wantFailed, gotFailed)
+			}
+			if gotFindings != testCase.wantFindings {
+				t.Fatalf("want: %d findings got: %d", testCase.wantFindings, gotFindings)
+			}
+		})
+	}
+}
+
+func Test_validateSBOMRules(t *testing.T) {
+	testTable := []struct {
+		label        string
+		filename     string
+		configure    func(*Config)
+		wantFailed   []string
+		wantFindings int
+	}{
+		{
+			label:      "syft-no-rules",
+			filename:   "syft-report.json",
+			configure:  func(c *Config) {},
+			wantFailed: []string{},
+		},
+		{
+			label:    "syft-package-denied-any-version",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.SBOM.PackageLimit = configSBOMPackageLimit{Enabled: true, Packages: []configSBOMPackage{{Name: "bash"}}}
+			},
+			wantFailed:   []string{RulePackageDeny},
+			wantFindings: 1,
+		},
+		{
+			label:    "syft-package-denied-other-version",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.SBOM.PackageLimit = configSBOMPackageLimit{Enabled: true, Packages: []configSBOMPackage{{Name: "bash", Version: "4.4"}}}
+			},
+			wantFailed: []string{},
+		},
+		{
+			label:    "syft-require-version-and-purl",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.SBOM.RequireVersion = true
+				c.SBOM.RequirePURL = true
+			},
+			wantFailed:   []string{RuleRequireVersion, RuleRequirePURL},
+			wantFindings: 2,
+		},
+		{
+			label:    "syft-component-limit",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.SBOM.ComponentLimit = configLimit{Enabled: true, Limit: 10}
+			},
+			wantFailed:   []string{RuleComponentLimit},
+			wantFindings: 1,
+		},
+		{
+			label:    "syft-license-denied",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.Licenses = configLicenses{
+					Enabled:    true,
+					Denied:     []string{"GPL-3.0-*"},
+					Exceptions: []configSBOMPackage{{Name: "busybox"}},
+				}
+			},
+			wantFailed:   []string{RuleLicense},
+			wantFindings: 4,
+		},
+		{
+			label:    "cyclonedx-license-missing",
+			filename: "cyclonedx-syft-sbom.json",
+			configure: func(c *Config) {
+				c.Licenses = configLicenses{Enabled: true}
+			},
+			wantFailed:   []string{RuleLicense},
+			wantFindings: 8,
+		},
+		{
+			label:    "cyclonedx-require-purl",
+			filename: "cyclonedx-syft-sbom.json",
+			configure: func(c *Config) {
+				c.SBOM.RequireVersion = true
+				c.SBOM.RequirePURL = true
+				c.SBOM.ComponentLimit = configLimit{Enabled: true, Limit: 200}
+			},
+			wantFailed:   []string{RuleRequirePURL},
+			wantFindings: 1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := NewDefaultConfig()
+			testCase.configure(config)
+
+			result, _ := Validate(config, MustOpen("../../test/"+testCase.filename, t), testCase.filename)
+			if len(result.Artifacts) != 1 {
+				t.Fatalf("want: 1 artifact got: %d", len(result.Artifacts))
+			}
+
+			gotFailed := make([]string, 0)
+			gotFindings := 0
+			for _, failure := range result.Failures() {
+				gotFailed = append(gotFailed, failure.Rule)
+				gotFindings += len(failure.Findings)
+			}
+			if !slices.Equal(testCase.wantFailed, gotFailed) {
+				t.Fatalf("want: %v got: %v", testCase.wantFailed, gotFailed)
+			}
+			if gotFindings != testCase.wantFindings {
+				t.Fatalf("want: %d findings got: %d", testCase.wantFindings, gotFindings)
+			}
+		})
+	}
+}
+
+func TestValidate_result(t *testing.T) {
+	t.Run("grype-severity-limit", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.SeverityLimit.Critical.Enabled = true
+		config.Grype.SeverityLimit.Critical.Limit = 0
+
+		f := MustOpen("../../test/grype-report.json", t)
+		result, err := Validate(config, f, "grype-report.json")
+		if !errors.Is(err, ErrValidationFailure) {
+			t.Fatalf("want: %v got: %v", ErrValidationFailure, err)
+		}
+		if result.Passed() {
+			t.Fatal("want: failed result got: passed")
+		}
+
+		failures := result.Failures()
+		if len(failures) != 1 {
+			t.Fatalf("want: 1 failure got: %d", len(failures))
+		}
+		if failures[0].Rule != RuleSeverityLimit || failures[0].Artifact != "grype" {
+			t.Fatalf("want: %s grype got: %s %s", RuleSeverityLimit, failures[0].Rule, failures[0].Artifact)
+		}
+		if len(failures[0].Findings) != 8 {
+			t.Fatalf("want: 8 critical findings got: %d", len(failures[0].Findings))
+		}
+		if failures[0].Thresholds["critical"] != uint(0) {
+			t.Fatalf("want: critical threshold 0 got: %v", failures[0].Thresholds)
+		}
+	})
+
+	t.Run("grype-pass", func(t *testing.T) {
+		config := new(Config)
+		f := MustOpen("../../test/grype-report.json", t)
+		result, err := Validate(config, f, "grype-report.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Passed() || len(result.Artifacts) != 1 {
+			t.Fatalf("want: 1 passing artifact got: %+v", result.Artifacts)
+		}
+		for _, rule := range result.Artifacts[0].Rules {
+			if rule.Status != RuleSkipped {
+				t.Fatalf("want: rule %s skipped got: %s", rule.Rule, rule.Status)
+			}
+		}
+	})
+
+	t.Run("bad-decode", func(t *testing.T) {
+		config := new(Config)
+		_, err := Validate(config, strings.NewReader("{{"), "grype-report.json")
+		if err == nil || errors.Is(err, ErrValidationFailure) {
+			t.Fatalf("want: decoding error got: %v", err)
+		}
+	})
+}
+
+func MustOpen(filename string, t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+	return f
+}
+
+func Test_validateGrypeRules_failFast(t *testing.T) {
+	newReport := func() *artifacts.GrypeReportMin {
+		return &artifacts.GrypeReportMin{
+			Matches: []artifacts.GrypeMatch{
+				{Vulnerability: artifacts.GrypeVulnerability{Severity: "critical", ID: "cve-1"}},
+				{Vulnerability: artifacts.GrypeVulnerability{Severity: "critical", ID: "cve-2"}},
+			},
+		}
+	}
+	config := new(Config)
+	config.Grype.CVELimit.Enabled = true
+	config.Grype.CVELimit.CVEs = []configCVE{{ID: "cve-1"}, {ID: "cve-2"}}
+	config.Grype.SeverityLimit.Critical.Enabled = true
+	config.Grype.SeverityLimit.Critical.Limit = 0
+
+	t.Run("all-rules", func(t *testing.T) {
+		result := validateGrypeRules(config, newReport(), nil, nil, nil, false)
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 2 {
+			t.Fatalf("want: 2 failures got: %d", len(failures))
+		}
+		if len(failures[0].Findings) != 2 {
+			t.Fatalf("want: 2 denied cves got: %d", len(failures[0].Findings))
+		}
+	})
+
+	t.Run("fail-fast", func(t *testing.T) {
+		result := validateGrypeRules(config, newReport(), nil, nil, nil, true)
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 1 || failures[0].Rule != RuleCVEDeny {
+			t.Fatalf("want: 1 %s failure got: %+v", RuleCVEDeny, failures)
+		}
+	})
+}
+
+func TestValidate_failFast(t *testing.T) {
+	lcovReport := []byte("SF:main.go\nFNF:4\nFNH:1\nLF:10\nLH:5\nBRF:4\nBRH:1\nend_of_record\n")
+
+	testTable := []struct {
+		label     string
+		filename  string
+		src       func(t *testing.T) io.Reader
+		configure func(*Config)
+		wantAll   int
+	}{
+		{
+			label:    "sarif",
+			filename: "sarif-report.sarif",
+			configure: func(c *Config) {
+				c.Sarif.RuleLimit = configSarifRuleIDs{Enabled: true, RuleIDs: []string{"go/log-injection"}}
+				c.Sarif.LevelLimit.Error = configLimit{Enabled: true, Limit: 0}
+			},
+			wantAll: 2,
+		},
+		{
+			label:    "semgrep",
+			filename: "semgrep-sast-report.json",
+			configure: func(c *Config) {
+				c.Semgrep.SeverityLimit.Error = configLimit{Enabled: true, Limit: 0}
+				c.Semgrep.SeverityLimit.Warning = configLimit{Enabled: true, Limit: 0}
+				c.Semgrep.SeverityLimit.Info = configLimit{Enabled: true, Limit: 0}
+			},
+			wantAll: 1,
+		},
+		{
+			label:    "gitleaks",
+			filename: "gitleaks-report.json",
+			configure: func(c *Config) {
+				c.Gitleaks.LimitEnabled = true
+			},
+			wantAll: 1,
+		},
+		{
+			label:    "syft",
+			filename: "syft-report.json",
+			configure: func(c *Config) {
+				c.SBOM.RequireVersion = true
+				c.SBOM.RequirePURL = true
+			},
+			wantAll: 2,
+		},
+		{
+			label:    "cyclonedx-sbom",
+			filename: "cyclonedx-syft-sbom.json",
+			configure: func(c *Config) {
+				c.SBOM.RequirePURL = true
+				c.Licenses = configLicenses{Enabled: true}
+			},
+			wantAll: 2,
+		},
+		{
+			label:    "coverage",
+			filename: "lcov.info",
+			src:      func(t *testing.T) io.Reader { return bytes.NewReader(lcovReport) },
+			configure: func(c *Config) {
+				c.Coverage = configCoverageReport{LineThreshold: 0.9, FunctionThreshold: 0.9, BranchThreshold: 0.9}
+			},
+			wantAll: 3,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			src := func() io.Reader {
+				if testCase.src != nil {
+					return testCase.src(t)
+				}
+				return MustOpen("../../test/"+testCase.filename, t)
+			}
+			config := NewDefaultConfig()
+			testCase.configure(config)
+
+			result, _ := Validate(config, src(), testCase.filename)
+			if failures := result.Failures(); len(failures) != testCase.wantAll {
+				t.Fatalf("want: %d failures got: %+v", testCase.wantAll, failures)
+			}
+
+			// evaluation stops at the first failed rule
+			result, _ = Validate(config, src(), testCase.filename, WithFailFast(true))
+			failures := result.Failures()
+			rules := result.Artifacts[0].Rules
+			if len(failures) != 1 || rules[len(rules)-1].Rule != failures[0].Rule {
+				t.Fatalf("want: evaluation to stop at 1 failure got: %+v", rules)
+			}
+		})
+	}
+}
+
+func TestEncodeValidationResultTo(t *testing.T) {
+	config := new(Config)
+	config.Grype.CVERiskAcceptance.Enabled = true
+	config.Grype.CVERiskAcceptance.CVEs = []configCVE{{ID: "cve-1"}}
+	config.Grype.SeverityLimit.High.Enabled = true
+	config.Grype.SeverityLimit.High.Limit = 0
+	report := &artifacts.GrypeReportMin{
+		Matches: []artifacts.GrypeMatch{
+			{Vulnerability: artifacts.GrypeVulnerability{Severity: "High", ID: "cve-1"}},
+			{Vulnerability: artifacts.GrypeVulnerability{Severity: "Low", ID: "cve-2"}},
+		},
+	}
+	result := &ValidationResult{Artifacts: []*ArtifactResult{validateGrypeRules(config, report, nil, nil, nil, false)}}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := EncodeValidationResultTo(buf, result, format); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"cve-allow", "cve-1", "severity-limit", "high"} {
+				if !strings.Contains(buf.String(), want) {
+					t.Fatalf("want: %s in output got: %s", want, buf.String())
+				}
+			}
+		})
+	}
+
+	t.Run("accepted-and-counts", func(t *testing.T) {
+		artifact := result.Artifacts[0]
+		if artifact.Counts["high"] != 1 || artifact.Counts["low"] != 1 {
+			t.Fatalf("want: 1 high 1 low got: %v", artifact.Counts)
+		}
+		idx := slices.IndexFunc(artifact.Rules, func(r RuleResult) bool { return r.Rule == RuleCVEAllow })
+		if idx == -1 || len(artifact.Rules[idx].Accepted) != 1 {
+			t.Fatalf("want: 1 accepted cve got: %+v", artifact.Rules)
+		}
+	})
+
+	t.Run("bad-format", func(t *testing.T) {
+		if err := EncodeValidationResultTo(new(bytes.Buffer), result, "toml"); err == nil {
+			t.Fatal("want: unsupported format error got: nil")
+		}
+	})
+}
+
+func Test_validateGrypeRules_fixAvailability(t *testing.T) {
+	decodeReport := func(t *testing.T) *artifacts.GrypeReportMin {
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/grype-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+	notFixedCritical := 0
+	for _, match := range decodeReport(t).SelectBySeverity("critical") {
+		if !match.Vulnerability.Fixable() {
+			notFixedCritical++
+		}
+	}
+	if notFixedCritical == 0 {
+		t.Fatal("test report should have critical vulnerabilities without a fix")
+	}
+
+	t.Run("fixable-only", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 0}
+		config.Grype.FixAvailability.FixableOnly = true
+
+		result := validateGrypeRules(config, decodeReport(t), nil, nil, nil, false)
+		for _, rule := range result.Rules {
+			switch rule.Rule {
+			case RuleFixAvailable:
+				if len(rule.Accepted) != 0 || len(rule.SetAside) < notFixedCritical {
+					t.Fatalf("want: at least %d set aside and none accepted got: %d %d", notFixedCritical, len(rule.SetAside), len(rule.Accepted))
+				}
+			case RuleSeverityLimit:
+				if rule.Passed() {
+					t.Fatal("want: severity limit failure got: pass")
+				}
+				for _, finding := range rule.Findings {
+					if finding.FixedIn == "" {
+						t.Fatalf("want: only fixable findings got: %+v", finding)
+					}
+				}
+			case RuleNotFixedSeverity:
+				if rule.Status != RuleSkipped {
+					t.Fatalf("want: skipped got: %s", rule.Status)
+				}
+			}
+		}
+	})
+
+	t.Run("not-fixed-limit", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.FixAvailability.FixableOnly = true
+		config.Grype.FixAvailability.NotFixedSeverityLimit.Critical = configLimit{Enabled: true, Limit: uint(notFixedCritical - 1)}
+
+		result := validateGrypeRules(config, decodeReport(t), nil, nil, nil, false)
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 1 || failures[0].Rule != RuleNotFixedSeverity {
+			t.Fatalf("want: not fixed severity limit failure got: %+v", failures)
+		}
+		if len(failures[0].Findings) != notFixedCritical {
+			t.Fatalf("want: %d findings got: %d", notFixedCritical, len(failures[0].Findings))
+		}
+
+		config.Grype.FixAvailability.NotFixedSeverityLimit.Critical.Limit = uint(notFixedCritical)
+		if err := validateGrypeRules(config, decodeReport(t), nil, nil, nil, false).Err(); err != nil {
+			t.Fatalf("want: pass got: %v", err)
+		}
+	})
+}
+
+func Test_ruleCVSSLimit(t *testing.T) {
+	t.Run("grype", func(t *testing.T) {
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/grype-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		config := new(Config)
+		config.Grype.CVSSLimit = configCVSSLimit{Enabled: true, Score: 9.0}
+
+		result := ruleCVSSLimit(config.Grype, grypeVulnerabilities(report))
+		if result.Passed() || len(result.Findings) != 4 {
+			t.Fatalf("want: 4 findings at or above 9.0 got: %+v", result.Findings)
+		}
+	})
+
+	testTable := []struct {
+		label   string
+		version string
+		wantIDs []string
+	}{
+		{label: "highest-version", version: "", wantIDs: []string{"CVE-2022-3715", "CVE-2016-2781"}},
+		{label: "prefer-v2", version: "2", wantIDs: []string{"CVE-2022-3715", "CVE-2017-11164"}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run("cyclonedx-"+testCase.label, func(t *testing.T) {
+			report := &artifacts.CyclonedxReportMin{}
+			if err := json.NewDecoder(MustOpen("../../test/cyclonedx-trivy-sbom.json", t)).Decode(report); err != nil {
+				t.Fatal(err)
+			}
+			config := new(Config)
+			config.Cyclonedx.CVSSLimit = configCVSSLimit{Enabled: true, Score: 7.8, Version: testCase.version}
+
+			result := ruleCVSSLimit(config.Cyclonedx, cyclonedxVulnerabilities(report))
+			if len(result.Findings) != len(testCase.wantIDs) {
+				t.Fatalf("want: %v got: %+v", testCase.wantIDs, result.Findings)
+			}
+			for _, finding := range result.Findings {
+				if !slices.Contains(testCase.wantIDs, finding.ID) {
+					t.Fatalf("want: %v got: %s", testCase.wantIDs, finding.ID)
+				}
+			}
+		})
+	}
+
+	t.Run("no-severity-limits", func(t *testing.T) {
+		// vulnerabilities over the cvss limit aren't ignored when their severity has no limit
+		report := &artifacts.GrypeReportMin{}
+		if err := json.NewDecoder(MustOpen("../../test/grype-report.json", t)).Decode(report); err != nil {
+			t.Fatal(err)
+		}
+		config := new(Config)
+		config.Grype.CVSSLimit = configCVSSLimit{Enabled: true, Score: 9.0}
+		if validateGrypeRules(config, report, nil, nil, nil, false).Passed() {
+			t.Fatal("want: cvss limit failure got: pass")
+		}
+	})
+}
+
+func Test_ruleEPSSPercentile(t *testing.T) {
+	newReport := func() *artifacts.GrypeReportMin {
+		report := &artifacts.GrypeReportMin{}
+		for _, id := range []string{"cve-high-percentile", "cve-low-percentile", "cve-no-data"} {
+			match := artifacts.GrypeMatch{}
+			match.Vulnerability.ID = id
+			match.Vulnerability.Severity = "Medium"
+			report.Matches = append(report.Matches, match)
+		}
+		return report
+	}
+	data := &epss.Data{CVEs: map[string]epss.CVE{
+		"cve-high-percentile": {EPSS: "0.04", Percentile: "0.92"},
+		"cve-low-percentile":  {EPSS: "0.30", Percentile: "0.50"},
+	}}
+
+	t.Run("limit", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.EPSSLimit = configEPSSLimit{Enabled: true, Score: 0.01, Percentile: 0.9}
+
+		result := ruleEPSSLimit(config.Grype, grypeVulnerabilities(newReport()), data)
+		if result.Passed() || len(result.Findings) != 1 || result.Findings[0].ID != "cve-high-percentile" {
+			t.Fatalf("want: cve-high-percentile over the limit got: %+v", result.Findings)
+		}
+		if _, ok := result.Thresholds["percentile"]; !ok {
+			t.Fatalf("want: percentile threshold got: %v", result.Thresholds)
+		}
+	})
+
+	t.Run("acceptance", func(t *testing.T) {
+		config := new(Config)
+		config.Grype.EPSSRiskAcceptance = configEPSSRiskAcceptance{Enabled: true, Score: 0.5, Percentile: 0.6}
+
+		report := grypeVulnerabilities(newReport())
+		result := ruleEPSSAllow(config.Grype, report, data)
+		if len(result.Accepted) != 1 || result.Accepted[0].ID != "cve-low-percentile" {
+			t.Fatalf("want: cve-low-percentile accepted got: %+v", result.Accepted)
+		}
+		if len(report.Vulnerabilities) != 2 {
+			t.Fatalf("want: 2 remaining got: %d", len(report.Vulnerabilities))
+		}
+	})
+
+	t.Run("no-severity-limits", func(t *testing.T) {
+		// vulnerabilities over the percentile limit aren't ignored when their severity has no limit
+		config := new(Config)
+		config.Grype.EPSSLimit = configEPSSLimit{Enabled: true, Score: 0.5, Percentile: 0.9}
+		result := validateGrypeRules(config, newReport(), nil, data, nil, false)
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 1 || failures[0].Rule != RuleEPSSLimit {
+			t.Fatalf("want: epss limit failure got: %+v", failures)
+		}
+	})
+}
+
+func TestValidate_epssDate(t *testing.T) {
+	requested := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte("#model_version:v2023.03.01,score_date:2024-01-02T00:00:00Z\ncve,epss,percentile\nCVE-2021-1234,0.5,0.9\n"))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.Grype.EPSSLimit = configEPSSLimit{Enabled: true, Score: 0.9}
+
+	result, err := Validate(config, MustOpen("../../test/grype-report.json", t), "grype-report.json",
+		WithEPSSURL(server.URL+"/epss_scores-current.csv.gz"),
+		WithEPSSDate(time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requested != "/epss_scores-2024-01-02.csv.gz" {
+		t.Fatalf("want: dated scores file got: %s", requested)
+	}
+	want := EPSSSource{ScoreDate: "2024-01-02", ModelVersion: "v2023.03.01"}
+	if got := result.Artifacts[0].EPSS; got == nil || *got != want {
+		t.Fatalf("want: %+v got: %+v", want, got)
+	}
+}
+
+func Test_ruleKEVLimit_metadata(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	catalog := &kev.Catalog{Vulnerabilities: []kev.Vulnerability{
+		{CveID: "cve-past-due", DueDate: "2024-05-01", KnownRansomwareCampaignUse: "Unknown"},
+		{CveID: "cve-ransomware", DueDate: "2024-07-01", KnownRansomwareCampaignUse: "Known"},
+		{CveID: "cve-past-due-ransomware", DueDate: "2024-05-31", KnownRansomwareCampaignUse: "Known"},
+		{CveID: "cve-not-due", DueDate: "2024-06-01", KnownRansomwareCampaignUse: "Unknown"},
+	}}
+	report := &artifacts.TrivyReportMin{Results: []artifacts.TrivyResult{{}}}
+	for _, entry := range catalog.Vulnerabilities {
+		report.Results[0].Vulnerabilities = append(report.Results[0].Vulnerabilities, artifacts.TrivyVulnerability{VulnerabilityID: entry.CveID})
+	}
+
+	testTable := []struct {
+		label    string
+		limit    configKEVLimit
+		wantIDs  []string
+		warnings int
+	}{
+		{label: "any", limit: configKEVLimit{}, wantIDs: []string{"cve-past-due", "cve-ransomware", "cve-past-due-ransomware", "cve-not-due"}},
+		{label: "past-due", limit: configKEVLimit{PastDueOnly: true}, wantIDs: []string{"cve-past-due", "cve-past-due-ransomware"}, warnings: 2},
+		{label: "ransomware", limit: configKEVLimit{RansomwareOnly: true}, wantIDs: []string{"cve-ransomware", "cve-past-due-ransomware"}, warnings: 2},
+		{label: "both", limit: configKEVLimit{PastDueOnly: true, RansomwareOnly: true}, wantIDs: []string{"cve-past-due-ransomware"}, warnings: 3},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := new(Config)
+			config.Trivy.KEVLimitEnabled = true
+			config.Trivy.KEVLimit = testCase.limit
+
+			result := ruleKEVLimit(config.Trivy, trivyVulnerabilities(report), catalog)
+			gotIDs := make([]string, 0, len(result.Findings))
+			for _, finding := range result.Findings {
+				gotIDs = append(gotIDs, finding.ID)
+			}
+			if !slices.Equal(testCase.wantIDs, gotIDs) {
+				t.Fatalf("want: %v got: %v", testCase.wantIDs, gotIDs)
+			}
+			if len(result.Warnings) != testCase.warnings {
+				t.Fatalf("want: %d warnings got: %v", testCase.warnings, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestValidate_requireSignature(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(publicKey)
+	keyFilename := t.TempDir() + "/gatecheck.pub"
+	if err := os.WriteFile(keyFilename, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newBundle := func(signed bool) *bytes.Buffer {
+		bundle := archive.NewBundle()
+		_ = bundle.AddFrom(MustOpen("../../test/gitleaks-report.json", t), "gitleaks-report.json", nil)
+		if signed {
+			_ = bundle.Sign(privateKey)
+		}
+		buf := new(bytes.Buffer)
+		if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	testTable := []struct {
+		label    string
+		src      io.Reader
+		filename string
+		wantErr  bool
+	}{
+		{label: "signed", src: newBundle(true), filename: "gatecheck-bundle.tar.gz"},
+		{label: "unsigned", src: newBundle(false), filename: "gatecheck-bundle.tar.gz", wantErr: true},
+		{label: "not-a-bundle", src: MustOpen("../../test/gitleaks-report.json", t), filename: "gitleaks-report.json", wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.label, func(t *testing.T) {
+			config := NewDefaultConfig()
+			_, err := Validate(config, testCase.src, testCase.filename, WithRequireSignature(MustOpen(keyFilename, t)))
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("want error: %v got: %v", testCase.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_bundleOrder(t *testing.T) {
+	bundle := archive.NewBundle()
+	for _, label := range []string{"c-gitleaks-report.json", "a-gitleaks-report.json", "b-semgrep-report.json"} {
+		report := "../../test/gitleaks-report.json"
+		if strings.Contains(label, "semgrep") {
+			report = "../../test/semgrep-sast-report.json"
+		}
+		if err := bundle.AddFrom(MustOpen(report, t), label, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf := new(bytes.Buffer)
+	if _, err := archive.TarGzipBundle(buf, bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	// artifacts are in label order on every run
+	want := []string{"a-gitleaks-report.json", "b-semgrep-report.json", "c-gitleaks-report.json"}
+	for range 5 {
+		result, _ := Validate(NewDefaultConfig(), bytes.NewReader(buf.Bytes()), "gatecheck-bundle.tar.gz")
+		got := make([]string, 0, len(result.Artifacts))
+		for _, artifact := range result.Artifacts {
+			got = append(got, artifact.Label)
+		}
+		if !slices.Equal(want, got) {
+			t.Fatalf("want: %v got: %v", want, got)
+		}
+	}
+}
+
+func Test_validateVulnerabilityRules_reportTypes(t *testing.T) {
+	// the same policy applies the same way to each report type
+	catalog := &kev.Catalog{Vulnerabilities: []kev.Vulnerability{{CveID: "cve-exploited"}, {CveID: "cve-critical"}}}
+
+	grypeReport := &artifacts.GrypeReportMin{}
+	for id, severity := range map[string]string{"cve-exploited": "Low", "cve-other": "Low", "cve-critical": "Critical"} {
+		match := artifacts.GrypeMatch{}
+		match.Vulnerability.ID = id
+		match.Vulnerability.Severity = severity
+		match.Artifact.Name = "openssl"
+		grypeReport.Matches = append(grypeReport.Matches, match)
+	}
+	trivyReport := &artifacts.TrivyReportMin{Results: []artifacts.TrivyResult{{Vulnerabilities: []artifacts.TrivyVulnerability{
+		{VulnerabilityID: "cve-exploited", Severity: "LOW", PkgName: "openssl"},
+		{VulnerabilityID: "cve-other", Severity: "LOW", PkgName: "openssl"},
+		{VulnerabilityID: "cve-critical", Severity: "CRITICAL", PkgName: "openssl"},
+	}}}}
+
+	config := new(Config)
+	config.Grype.KEVLimitEnabled = true
+	config.Grype.SeverityLimit.Critical = configLimit{Enabled: true, Limit: 1}
+	config.Trivy = config.Grype
+
+	results := []*ArtifactResult{
+		validateGrypeRules(config, grypeReport, catalog, nil, nil, false),
+		validateTrivyRules(config, trivyReport, catalog, nil, nil, false),
+	}
+	for _, result := range results {
+		// the low severity KEV match is ignored without a low severity limit
+		failures := (&ValidationResult{Artifacts: []*ArtifactResult{result}}).Failures()
+		if len(failures) != 1 || failures[0].Rule != RuleKEVLimit || len(failures[0].Findings) != 1 || failures[0].Findings[0].ID != "cve-critical" {
+			t.Fatalf("%s want: kev limit failure for only the critical severity got: %+v", result.Type, failures)
+		}
+		if result.Counts["low"] != 2 || result.Counts["critical"] != 1 {
+			t.Fatalf("%s want: 2 low and 1 critical got: %v", result.Type, result.Counts)
+		}
+	}
+}
+
+func Test_removeIgnoredSeverityCVEs_epssPercentile(t *testing.T) {
+	// a percentile over the EPSS limit keeps a vulnerability of a severity without a limit
+	data := &epss.Data{CVEs: map[string]epss.CVE{
+		"cve-percentile": {EPSS: "0.01000", Percentile: "0.80000"},
+		"cve-quiet":      {EPSS: "0.01000", Percentile: "0.10000"},
+	}}
+	config := reportWithCVEs{EPSSLimit: configEPSSLimit{Enabled: true, Score: 0.9, Percentile: 0.5}}
+
+	grypeReport := &artifacts.GrypeReportMin{}
+	for _, id := range []string{"cve-percentile", "cve-quiet"} {
+		match := artifacts.GrypeMatch{}
+		match.Vulnerability.ID = id
+		match.Vulnerability.Severity = "Low"
+		grypeReport.Matches = append(grypeReport.Matches, match)
+	}
+	cyclonedxReport := &artifacts.CyclonedxReportMin{Vulnerabilities: []artifacts.CyclonedxVulnerability{
+		{ID: "cve-percentile", Ratings: []artifacts.CyclonedxRating{{Severity: "low"}}},
+		{ID: "cve-quiet", Ratings: []artifacts.CyclonedxRating{{Severity: "low"}}},
+	}}
+	trivyReport := &artifacts.TrivyReportMin{Results: []artifacts.TrivyResult{{Vulnerabilities: []artifacts.TrivyVulnerability{
+		{VulnerabilityID: "cve-percentile", Severity: "LOW"},
+		{VulnerabilityID: "cve-quiet", Severity: "LOW"},
+	}}}}
+
+	for _, report := range []*vulnerabilityReport{
+		grypeVulnerabilities(grypeReport),
+		cyclonedxVulnerabilities(cyclonedxReport),
+		trivyVulnerabilities(trivyReport),
+	} {
+		removeIgnoredSeverityCVEs(config, report, nil, data)
+		if len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].ID != "cve-percentile" {
+			t.Fatalf("%s want: cve-percentile kept got: %+v", report.Artifact, report.Vulnerabilities)
+		}
+	}
+}
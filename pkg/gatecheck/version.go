@@ -0,0 +1,145 @@
+package gatecheck
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// revisionEcosystems the package types where the suffix after "-" is the package revision
+var revisionEcosystems = []string{"deb", "rpm"}
+
+// compareVersions a best effort comparison of package versions across ecosystems
+//
+// Versions are split into runs of digits and letters, ex. 1.1.1n-0+deb11u4,
+// digit runs are compared numerically and letter runs lexically.
+// A leading "v" and trailing zero parts are ignored so v1.2 and 1.2.0 are equal.
+// A suffix after "-" is a pre-release that sorts before the release, ex. 1.0.0-rc1 < 1.0.0,
+// unless the ecosystem is deb or rpm where it's the package revision
+func compareVersions(a string, b string, ecosystem string) int {
+	a, b = strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")
+	if slices.Contains(revisionEcosystems, strings.ToLower(ecosystem)) {
+		return compareVersionParts(versionParts(a), versionParts(b))
+	}
+
+	releaseA, preA, hasPreA := strings.Cut(a, "-")
+	releaseB, preB, hasPreB := strings.Cut(b, "-")
+	if c := compareVersionParts(versionParts(releaseA), versionParts(releaseB)); c != 0 {
+		return c
+	}
+	switch {
+	case hasPreA && !hasPreB:
+		return -1
+	case !hasPreA && hasPreB:
+		return 1
+	}
+	return compareVersionParts(versionParts(preA), versionParts(preB))
+}
+
+func compareVersionParts(partsA []string, partsB []string) int {
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		switch {
+		case i >= len(partsA) && zeroParts(partsB[i:]):
+			return 0
+		case i >= len(partsA):
+			return -1
+		case i >= len(partsB) && zeroParts(partsA[i:]):
+			return 0
+		case i >= len(partsB):
+			return 1
+		}
+		numA, errA := strconv.ParseUint(partsA[i], 10, 64)
+		numB, errB := strconv.ParseUint(partsB[i], 10, 64)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = cmp.Compare(numA, numB)
+		case errA == nil:
+			// a number sorts after a letter, ex. 1.0.1 > 1.0.beta
+			c = 1
+		case errB == nil:
+			c = -1
+		default:
+			c = cmp.Compare(partsA[i], partsB[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// zeroParts true if every part is the number zero, ex. the trailing .0 in 1.0.0
+func zeroParts(parts []string) bool {
+	for _, part := range parts {
+		if num, err := strconv.ParseUint(part, 10, 64); err != nil || num != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func versionParts(version string) []string {
+	parts := make([]string, 0)
+	current := new(strings.Builder)
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range version {
+		switch {
+		case !unicode.IsDigit(r) && !unicode.IsLetter(r):
+			flush()
+			continue
+		case current.Len() > 0:
+			last := rune(current.String()[current.Len()-1])
+			if unicode.IsDigit(last) != unicode.IsDigit(r) {
+				flush()
+			}
+		}
+		current.WriteRune(r)
+	}
+	flush()
+	return parts
+}
+
+// versionInRange evaluates a comma separated list of constraints, ex. ">=1.2.0, <2.0.0"
+//
+// Supported operators are =, !=, >, >=, <, <= and a version without an operator must be equal.
+// Every constraint must be satisfied, the ecosystem is the package type, ex. deb
+func versionInRange(version string, versionRange string, ecosystem string) bool {
+	for _, constraint := range strings.Split(versionRange, ",") {
+		constraint = strings.TrimSpace(constraint)
+		if constraint == "" {
+			continue
+		}
+
+		operator := constraint[:len(constraint)-len(strings.TrimLeft(constraint, "<>=!"))]
+		c := compareVersions(version, strings.TrimSpace(constraint[len(operator):]), ecosystem)
+
+		var satisfied bool
+		switch operator {
+		case "", "=", "==":
+			satisfied = c == 0
+		case "!=":
+			satisfied = c != 0
+		case ">":
+			satisfied = c > 0
+		case ">=":
+			satisfied = c >= 0
+		case "<":
+			satisfied = c < 0
+		case "<=":
+			satisfied = c <= 0
+		}
+		if !satisfied {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package gatecheck
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/gatecheckdev/gatecheck/pkg/artifacts"
+)
+
+// Vulnerability Reports
+//
+// Grype, Cyclonedx and Trivy reports are converted to the same view
+// so every CVE rule is written once and applies the same way to each report type
+
+// vulnerability a single vulnerability from a report
+type vulnerability struct {
+	ID string
+	// Severity lower case, unknown if the report doesn't have one
+	Severity string
+	// Scopes the affected packages, a Cyclonedx vulnerability can affect more than one component
+	Scopes     []packageScope
+	CVSSScores []artifacts.CVSSScore
+	Fixable    bool
+	// FixState the reported reason there isn't a fix, ex. wont-fix
+	FixState string
+	// Finding the vulnerability as it is reported in rule results
+	Finding Finding
+}
+
+// vulnerabilityReport the vulnerabilities of one artifact, rules that accept or set aside
+// vulnerabilities remove them from the report so subsequent rules don't evaluate them
+type vulnerabilityReport struct {
+	Artifact        string
+	Vulnerabilities []vulnerability
+}
+
+func grypeVulnerabilities(report *artifacts.GrypeReportMin) *vulnerabilityReport {
+	vulnerabilities := make([]vulnerability, 0, len(report.Matches))
+	for _, match := range report.Matches {
+		vulnerabilities = append(vulnerabilities, vulnerability{
+			ID:         match.Vulnerability.ID,
+			Severity:   strings.ToLower(cmp.Or(match.Vulnerability.Severity, "unknown")),
+			Scopes:     []packageScope{grypeScope(match.Artifact)},
+			CVSSScores: match.Vulnerability.CVSSScores(),
+			Fixable:    match.Vulnerability.Fixable(),
+			FixState:   cmp.Or(match.Vulnerability.Fix.State, "unknown"),
+			Finding:    grypeFinding(match),
+		})
+	}
+	return &vulnerabilityReport{Artifact: "grype", Vulnerabilities: vulnerabilities}
+}
+
+func cyclonedxVulnerabilities(report *artifacts.CyclonedxReportMin) *vulnerabilityReport {
+	vulnerabilities := make([]vulnerability, 0, len(report.Vulnerabilities))
+	for idx, cyclonedxVulnerability := range report.Vulnerabilities {
+		finding := cyclonedxFinding(cyclonedxVulnerability)
+		finding.Package = report.AffectedPackages(idx)
+		vulnerabilities = append(vulnerabilities, vulnerability{
+			ID:         cyclonedxVulnerability.ID,
+			Severity:   strings.ToLower(cmp.Or(finding.Severity, "unknown")),
+			Scopes:     cyclonedxScopes(report, idx),
+			CVSSScores: cyclonedxVulnerability.CVSSScores(),
+			Fixable:    cyclonedxVulnerability.Fixable(),
+			Finding:    finding,
+		})
+	}
+	return &vulnerabilityReport{Artifact: "cyclonedx", Vulnerabilities: vulnerabilities}
+}
+
+func trivyVulnerabilities(report *artifacts.TrivyReportMin) *vulnerabilityReport {
+	vulnerabilities := make([]vulnerability, 0)
+	for _, trivyVulnerability := range report.Vulnerabilities() {
+		vulnerabilities = append(vulnerabilities, vulnerability{
+			ID:         trivyVulnerability.VulnerabilityID,
+			Severity:   strings.ToLower(cmp.Or(trivyVulnerability.Severity, "unknown")),
+			Scopes:     []packageScope{trivyScope(trivyVulnerability)},
+			CVSSScores: trivyVulnerability.CVSSScores(),
+			Fixable:    trivyVulnerability.Fixable(),
+			FixState:   cmp.Or(trivyVulnerability.Status, "unknown"),
+			Finding:    trivyFinding(trivyVulnerability),
+		})
+	}
+	return &vulnerabilityReport{Artifact: "trivy", Vulnerabilities: vulnerabilities}
+}
+
+// findingWithDetail the reported finding with the reason for the rule result
+func (v vulnerability) findingWithDetail(detail string) Finding {
+	finding := v.Finding
+	finding.Detail = detail
+	return finding
+}
+
+// matchedBy true if the entry matches any affected package, a scoped deny fails on any match
+func (v vulnerability) matchedBy(cve configCVE) bool {
+	return slices.ContainsFunc(v.Scopes, func(scope packageScope) bool {
+		return matchesCVE(cve, v.ID, scope)
+	})
+}
+
+// coveredBy true if the entry matches every affected package, a scoped acceptance must cover all of them
+func (v vulnerability) coveredBy(cve configCVE) bool {
+	return !slices.ContainsFunc(v.Scopes, func(scope packageScope) bool {
+		return !matchesCVE(cve, v.ID, scope)
+	})
+}
+
+func (r *vulnerabilityReport) selectBySeverity(severity string) []vulnerability {
+	vulnerabilities := make([]vulnerability, 0)
+	for _, v := range r.Vulnerabilities {
+		if v.Severity == severity {
+			vulnerabilities = append(vulnerabilities, v)
+		}
+	}
+	return vulnerabilities
+}
@@ -1,12 +1,16 @@
 package kev
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -21,6 +25,8 @@ type Catalog struct {
 	DateReleased    time.Time       `json:"dateReleased"`
 	Count           int             `json:"count"`
 	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	// index the position of each vulnerability by the lower case CVE ID
+	index map[string]int
 }
 
 // Vulnerability data model for a single record
@@ -33,7 +39,27 @@ type Vulnerability struct {
 	ShortDescription  string `json:"shortDescription"`
 	RequiredAction    string `json:"requiredAction"`
 	DueDate           string `json:"dueDate"`
-	Notes             string `json:"notes"`
+	// KnownRansomwareCampaignUse is "Known" if the vulnerability is used in ransomware campaigns, otherwise "Unknown"
+	KnownRansomwareCampaignUse string `json:"knownRansomwareCampaignUse"`
+	Notes                      string `json:"notes"`
+}
+
+// DueDateLayout the format of the dateAdded and dueDate fields
+const DueDateLayout = "2006-01-02"
+
+// KnownRansomwareUse true if the vulnerability is known to be used in ransomware campaigns
+func (v Vulnerability) KnownRansomwareUse() bool {
+	return strings.EqualFold(v.KnownRansomwareCampaignUse, "Known")
+}
+
+// PastDue true if the due date is before the date, false if the due date is missing or invalid
+func (v Vulnerability) PastDue(date time.Time) bool {
+	dueDate, err := time.Parse(DueDateLayout, v.DueDate)
+	if err != nil {
+		return false
+	}
+	year, month, day := date.Date()
+	return dueDate.Before(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
 }
 
 func NewCatalog() *Catalog {
@@ -42,6 +68,32 @@ func NewCatalog() *Catalog {
 	}
 }
 
+// Lookup the catalog entry for the CVE ID, case insensitive
+//
+// The index is built when the catalog is decoded, or on the first lookup
+// for a catalog created another way
+func (c *Catalog) Lookup(cveID string) (Vulnerability, bool) {
+	if c.index == nil {
+		c.buildIndex()
+	}
+	idx, ok := c.index[strings.ToLower(cveID)]
+	if !ok {
+		return Vulnerability{}, false
+	}
+	return c.Vulnerabilities[idx], true
+}
+
+// buildIndex the first entry is kept if a CVE ID is listed more than once
+func (c *Catalog) buildIndex() {
+	c.index = make(map[string]int, len(c.Vulnerabilities))
+	for idx, vulnerability := range c.Vulnerabilities {
+		key := strings.ToLower(vulnerability.CveID)
+		if _, ok := c.index[key]; !ok {
+			c.index[key] = idx
+		}
+	}
+}
+
 type FetchOptions struct {
 	Client *http.Client
 	URL    string
@@ -84,8 +136,9 @@ func DownloadData(w io.Writer, optionFuncs ...fetchOptionFunc) error {
 
 	switch {
 	case err != nil:
+		// the transport error is wrapped, ex. the caller checks for an offline cache miss
 		logger.Error("kev api request failed during fetch data", "error", err)
-		return errors.New("failed to get KEV Catalog. see log for details")
+		return fmt.Errorf("failed to get KEV Catalog: %w", err)
 	case res.StatusCode != http.StatusOK:
 		logger.Error("kev api bad status code", "res_status", res.Status)
 		return errors.New("failed to get KEV Catalog. see log for details")
@@ -110,11 +163,98 @@ func FetchData(catalog *Catalog, optionFuncs ...fetchOptionFunc) error {
 	return DecodeData(buf, catalog)
 }
 
+// DecodeData decodes the catalog from the JSON or CSV format
+//
+// The format is detected from the content, JSON starts with an object
 func DecodeData(r io.Reader, catalog *Catalog) error {
-	if err := json.NewDecoder(r).Decode(catalog); err != nil {
+	bufReader := bufio.NewReader(r)
+	if isCSV(bufReader) {
+		return DecodeCSV(bufReader, catalog)
+	}
+	if err := json.NewDecoder(bufReader).Decode(catalog); err != nil {
 		slog.Error("kev decoding failure", "error", err)
 		return errors.New("failed to get KEV Catalog. see log for details")
+	}
+	catalog.buildIndex()
+	return nil
+}
 
+func isCSV(r *bufio.Reader) bool {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return false
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		case 0xEF:
+			// UTF-8 byte order mark
+			if bom, err := r.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+				_, _ = r.Discard(3)
+				continue
+			}
+			return true
+		default:
+			return b[0] != '{'
+		}
 	}
+}
+
+// DecodeCSV decodes the CSV format of the catalog, columns are matched by the header names
+//
+// The catalog version and release date aren't part of the CSV format
+func DecodeCSV(r io.Reader, catalog *Catalog) error {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		slog.Error("kev csv header decoding failure", "error", err)
+		return errors.New("failed to get KEV Catalog. see log for details")
+	}
+	columns := make(map[string]int, len(header))
+	for idx, name := range header {
+		columns[strings.TrimSpace(name)] = idx
+	}
+	if _, ok := columns["cveID"]; !ok {
+		slog.Error("kev csv missing cveID column", "header", header)
+		return errors.New("failed to get KEV Catalog. see log for details")
+	}
+
+	vulnerabilities := make([]Vulnerability, 0)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			slog.Error("kev csv decoding failure", "error", err)
+			return errors.New("failed to get KEV Catalog. see log for details")
+		}
+		field := func(name string) string {
+			idx, ok := columns[name]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return record[idx]
+		}
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			CveID:                      field("cveID"),
+			VendorProject:              field("vendorProject"),
+			Product:                    field("product"),
+			VulnerabilityName:          field("vulnerabilityName"),
+			DateAdded:                  field("dateAdded"),
+			ShortDescription:           field("shortDescription"),
+			RequiredAction:             field("requiredAction"),
+			DueDate:                    field("dueDate"),
+			KnownRansomwareCampaignUse: field("knownRansomwareCampaignUse"),
+			Notes:                      field("notes"),
+		})
+	}
+
+	catalog.Vulnerabilities = vulnerabilities
+	catalog.Count = len(vulnerabilities)
+	catalog.buildIndex()
 	return nil
 }
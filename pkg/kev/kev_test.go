@@ -0,0 +1,57 @@
+package kev
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeData(t *testing.T) {
+	for _, filename := range []string{"known_exploited_vulnerabilities.json", "known_exploited_vulnerabilities.csv"} {
+		t.Run(filename, func(t *testing.T) {
+			f, err := os.Open("../../test/" + filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			catalog := NewCatalog()
+			if err := DecodeData(f, catalog); err != nil {
+				t.Fatal(err)
+			}
+			if len(catalog.index) == 0 {
+				t.Fatal("want: index built on decode got: empty index")
+			}
+			if _, ok := catalog.Lookup("CVE-0000-0000"); ok {
+				t.Fatal("want: CVE-0000-0000 not found got: found")
+			}
+			entry, ok := catalog.Lookup("cve-2021-27104")
+			if !ok {
+				t.Fatalf("want: CVE-2021-27104 in %d entries got: not found", len(catalog.Vulnerabilities))
+			}
+			if entry.VendorProject != "Accellion" || entry.DueDate != "2021-11-17" {
+				t.Fatalf("want: Accellion due 2021-11-17 got: %+v", entry)
+			}
+		})
+	}
+}
+
+func TestDecodeCSV_ransomware(t *testing.T) {
+	src := strings.NewReader("cveID,vendorProject,dueDate,knownRansomwareCampaignUse,notes,cwes\n" +
+		"CVE-2024-0001,Example,2024-02-01,Known,,CWE-78\n" +
+		"CVE-2024-0002,Example,2024-03-01,Unknown,,\n")
+
+	catalog := NewCatalog()
+	if err := DecodeCSV(src, catalog); err != nil {
+		t.Fatal(err)
+	}
+	if catalog.Count != 2 || !catalog.Vulnerabilities[0].KnownRansomwareUse() || catalog.Vulnerabilities[1].KnownRansomwareUse() {
+		t.Fatalf("want: 2 entries, the first with known ransomware use got: %+v", catalog.Vulnerabilities)
+	}
+
+	date := time.Date(2024, time.February, 15, 12, 0, 0, 0, time.UTC)
+	if !catalog.Vulnerabilities[0].PastDue(date) || catalog.Vulnerabilities[1].PastDue(date) {
+		t.Fatalf("want: only the first entry past due on %s got: %+v", date, catalog.Vulnerabilities)
+	}
+}